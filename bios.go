@@ -0,0 +1,522 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+//BIOS passwords accepted by ChangeBiosPasswordDell and ChangeBiosPasswordHP
+const (
+	BiosSetupPassword  = "SetupPassword"
+	BiosSystemPassword = "SysPassword"
+)
+
+//String ... will return the attribute as a string, numbers and booleans are formatted
+func (b BiosAttributes) String(name string) (string, bool) {
+	v, ok := b[name]
+	if !ok || v == nil {
+		return "", false
+	}
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(s), true
+	}
+	return fmt.Sprint(v), true
+}
+
+//Int ... will return the attribute as an int, firmware returning numbers as strings is handled
+func (b BiosAttributes) Int(name string) (int, bool) {
+	v, ok := b[name]
+	if !ok {
+		return 0, false
+	}
+	if n, ok := toFloat(v); ok {
+		return int(n), true
+	}
+	if s, ok := v.(string); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		return n, err == nil
+	}
+	return 0, false
+}
+
+//Bool ... will return the attribute as a bool, "true"/"false" strings are handled
+func (b BiosAttributes) Bool(name string) (bool, bool) {
+	v, ok := b[name]
+	if !ok {
+		return false, false
+	}
+	switch t := v.(type) {
+	case bool:
+		return t, true
+	case string:
+		r, err := strconv.ParseBool(t)
+		return r, err == nil
+	}
+	return false, false
+}
+
+//Has ... will report if the server exposes the attribute
+func (b BiosAttributes) Has(name string) bool {
+	_, ok := b[name]
+	return ok
+}
+
+//AttrInt ... Integer attribute some firmware returns as a JSON string ("0" instead of 0)
+type AttrInt int
+
+//UnmarshalJSON ... will accept the value as a number, a numeric string or null
+func (n *AttrInt) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(strings.Trim(string(data), `"`))
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("attribute value %s is not an integer", data)
+	}
+	*n = AttrInt(v)
+
+	return nil
+}
+
+//Error ... will list every rejected attribute with its reason
+func (e BiosValidationError) Error() string {
+	var msgs []string
+	for _, a := range e {
+		msgs = append(msgs, a.Attribute+": "+a.Message)
+	}
+	return "invalid BIOS settings: " + strings.Join(msgs, "; ")
+}
+
+//getBiosRegistry ... will fetch the attribute registry published under the given registry name
+// The registries collection entry points at the file holding the RegistryEntries.
+func getBiosRegistry(c *IloClient, name string) (BiosRegistry, error) {
+	url := c.Hostname + "/redfish/v1/Registries/" + name
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return BiosRegistry{}, err
+	}
+
+	var (
+		x BiosRegistry
+		y RegistryFile
+	)
+
+	json.Unmarshal(resp, &x)
+	if len(x.RegistryEntries.Attributes) > 0 {
+		return x, nil
+	}
+
+	json.Unmarshal(resp, &y)
+
+	for _, loc := range y.Location {
+		if loc.Language != "" && loc.Language != "en" {
+			continue
+		}
+
+		var uri string
+		switch u := loc.URI.(type) {
+		case string:
+			uri = u
+		case map[string]interface{}:
+			uri, _ = u["extref"].(string)
+		}
+		if uri == "" {
+			continue
+		}
+
+		resp, _, _, err := queryData(c, "GET", c.Hostname+uri, nil)
+		if err != nil {
+			return BiosRegistry{}, err
+		}
+
+		json.Unmarshal(resp, &x)
+		return x, nil
+	}
+
+	return BiosRegistry{}, fmt.Errorf("registry %s has no location", name)
+}
+
+//validateBiosAttributes ... will check the changes against the registry
+// current holds the live attribute values, used to evaluate the registry dependencies.
+func validateBiosAttributes(reg BiosRegistry, current BiosAttributes, changes map[string]interface{}) error {
+	var errs BiosValidationError
+
+	defs := make(map[string]BiosRegistryAttribute)
+	for _, def := range reg.RegistryEntries.Attributes {
+		defs[biosAttributeName(def)] = def
+	}
+
+	// Dependencies are evaluated against the values the BIOS will have after the change
+	effective := mergeBiosAttributes(current, changes)
+
+	for name, value := range changes {
+		def, ok := defs[name]
+		if !ok {
+			errs = append(errs, BiosAttributeError{Attribute: name, Message: "unknown attribute"})
+			continue
+		}
+		if def.ReadOnly {
+			errs = append(errs, BiosAttributeError{Attribute: name, Message: "attribute is read-only"})
+			continue
+		}
+		if msg := checkBiosValue(def, value); msg != "" {
+			errs = append(errs, BiosAttributeError{Attribute: name, Message: msg})
+			continue
+		}
+		if msg := checkBiosDependencies(reg, name, effective); msg != "" {
+			errs = append(errs, BiosAttributeError{Attribute: name, Message: msg})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+//biosAttributeName ... iLO 4 registries use Name instead of AttributeName
+func biosAttributeName(def BiosRegistryAttribute) string {
+	if def.AttributeName != "" {
+		return def.AttributeName
+	}
+	return def.Name
+}
+
+//checkBiosValue ... will return why the value does not fit the attribute definition
+func checkBiosValue(def BiosRegistryAttribute, value interface{}) string {
+	switch def.Type {
+	case "Enumeration", "Enum":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected a string, got %T", value)
+		}
+		var allowed []string
+		for _, v := range def.Value {
+			if v.ValueName == s {
+				return ""
+			}
+			allowed = append(allowed, v.ValueName)
+		}
+		return fmt.Sprintf("%q is not one of %s", s, strings.Join(allowed, ", "))
+
+	case "String", "Password":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected a string, got %T", value)
+		}
+		if def.MinLength != nil && len(s) < *def.MinLength {
+			return fmt.Sprintf("shorter than %d characters", *def.MinLength)
+		}
+		if def.MaxLength != nil && len(s) > *def.MaxLength {
+			return fmt.Sprintf("longer than %d characters", *def.MaxLength)
+		}
+		if def.ValueExpression != "" && s != "" {
+			r, err := regexp.Compile(def.ValueExpression)
+			if err == nil && !r.MatchString(s) {
+				return fmt.Sprintf("%q does not match %s", s, def.ValueExpression)
+			}
+		}
+
+	case "Integer":
+		n, ok := toFloat(value)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Sprintf("expected an integer, got %v", value)
+		}
+		if def.LowerBound != nil && n < *def.LowerBound {
+			return fmt.Sprintf("%v is below the lower bound %v", value, *def.LowerBound)
+		}
+		if def.UpperBound != nil && n > *def.UpperBound {
+			return fmt.Sprintf("%v is above the upper bound %v", value, *def.UpperBound)
+		}
+		if def.ScalarIncrement != nil && *def.ScalarIncrement > 0 {
+			base := 0.0
+			if def.LowerBound != nil {
+				base = *def.LowerBound
+			}
+			// float math, increments below 1 would truncate to a zero modulus
+			steps := (n - base) / *def.ScalarIncrement
+			if math.Abs(steps-math.Round(steps)) > 1e-9 {
+				return fmt.Sprintf("%v is not a multiple of %v", value, *def.ScalarIncrement)
+			}
+		}
+
+	case "Boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected a boolean, got %T", value)
+		}
+	}
+
+	return ""
+}
+
+//checkBiosDependencies ... will return why the attribute cannot be set with the given values
+// Only the dependencies that make the attribute read-only (or grayed out on iLO) are enforced.
+func checkBiosDependencies(reg BiosRegistry, name string, values map[string]interface{}) string {
+	for _, dep := range reg.RegistryEntries.Dependencies {
+		d := dep.Dependency
+		if d.MapToAttribute != name || (d.MapToProperty != "ReadOnly" && d.MapToProperty != "GrayOut") {
+			continue
+		}
+		if b, ok := d.MapToValue.(bool); !ok || !b {
+			continue
+		}
+
+		matched := false
+		var terms string
+		for i, from := range d.MapFrom {
+			ok := compareBiosValue(values[from.MapFromAttribute], from.MapFromCondition, from.MapFromValue)
+			term := fmt.Sprintf("%s %s %v", from.MapFromAttribute, from.MapFromCondition, from.MapFromValue)
+			if i == 0 {
+				matched, terms = ok, term
+			} else if from.MapTerms == "OR" {
+				matched, terms = matched || ok, terms+" OR "+term
+			} else {
+				matched, terms = matched && ok, terms+" AND "+term
+			}
+		}
+
+		if matched {
+			return "attribute is read-only while " + terms
+		}
+	}
+
+	return ""
+}
+
+//compareBiosValue ... will evaluate a registry MapFromCondition
+func compareBiosValue(actual interface{}, condition string, expected interface{}) bool {
+	a, aok := toFloat(actual)
+	e, eok := toFloat(expected)
+	numeric := aok && eok
+
+	switch condition {
+	case "EQU":
+		if numeric {
+			return a == e
+		}
+		return fmt.Sprint(actual) == fmt.Sprint(expected)
+	case "NEQ":
+		if numeric {
+			return a != e
+		}
+		return fmt.Sprint(actual) != fmt.Sprint(expected)
+	case "GTR":
+		return numeric && a > e
+	case "GEQ":
+		return numeric && a >= e
+	case "LSS":
+		return numeric && a < e
+	case "LEQ":
+		return numeric && a <= e
+	}
+
+	return false
+}
+
+//toFloat ... will convert the numeric types JSON and callers use to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+//diffBiosAttributes ... will list the staged attributes whose value differs from the current one
+func diffBiosAttributes(current BiosAttributes, staged BiosAttributes) []BiosPendingValue {
+	var diff []BiosPendingValue
+
+	for name, value := range staged {
+		if cur, ok := current[name]; ok && fmt.Sprint(cur) == fmt.Sprint(value) {
+			continue
+		}
+		diff = append(diff, BiosPendingValue{
+			Attribute: name,
+			Current:   current[name],
+			Pending:   value,
+		})
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].Attribute < diff[j].Attribute
+	})
+
+	return diff
+}
+
+//LoadBiosBaseline ... will read a golden BIOS profile from a .json, .yaml or .yml file
+// The file holds attribute names and values, optionally nested under "Attributes".
+// YAML reads unquoted On/Off/Yes/No as booleans, the baseline comparison maps them back to the
+// matching Enumeration value, String attributes holding such words have to be quoted.
+func LoadBiosBaseline(path string) (BiosAttributes, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var x map[string]interface{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &x)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &x)
+	default:
+		return nil, fmt.Errorf("unsupported baseline format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if attrs, ok := x["Attributes"]; ok && len(x) == 1 {
+		switch a := attrs.(type) {
+		case map[string]interface{}:
+			return BiosAttributes(a), nil
+		case map[interface{}]interface{}:
+			_attrs := make(BiosAttributes)
+			for k, v := range a {
+				_attrs[fmt.Sprint(k)] = v
+			}
+			return _attrs, nil
+		}
+	}
+
+	return BiosAttributes(x), nil
+}
+
+//compareBiosBaseline ... will report every baseline attribute that differs from the live value
+// Differences that can be set are collected in the plan, the others are reported with the reason.
+func compareBiosBaseline(reg BiosRegistry, current BiosAttributes, desired BiosAttributes) BiosDriftReport {
+	defs := make(map[string]BiosRegistryAttribute)
+	for _, def := range reg.RegistryEntries.Attributes {
+		defs[biosAttributeName(def)] = def
+	}
+
+	report := BiosDriftReport{Plan: BiosApplyPlan{Attributes: make(map[string]interface{})}}
+
+	_desired := make(BiosAttributes)
+	for name, expected := range desired {
+		if b, ok := expected.(bool); ok {
+			if v := biosEnumFromBool(defs[name], b); v != "" {
+				expected = v
+			}
+		}
+		_desired[name] = expected
+	}
+	desired = _desired
+
+	for name, expected := range desired {
+		actual, ok := current[name]
+		if ok && biosValueEqual(actual, expected) {
+			continue
+		}
+
+		drift := BiosDrift{Attribute: name, Expected: expected, Actual: actual}
+		def, known := defs[name]
+		switch {
+		case !ok || !known:
+			drift.Reason = "unknown attribute"
+		case def.ReadOnly:
+			drift.Reason = "attribute is read-only"
+		default:
+			drift.Reason = checkBiosValue(def, expected)
+			if _, ok := expected.(bool); ok && def.Type == "String" {
+				drift.Reason = "boolean given for a string attribute, quote the value in YAML"
+			}
+			if drift.Reason == "" {
+				drift.Reason = checkBiosDependencies(reg, name, mergeBiosAttributes(current, desired))
+			}
+		}
+		drift.Settable = drift.Reason == ""
+
+		if drift.Settable {
+			report.Plan.Attributes[name] = expected
+		}
+		report.Drift = append(report.Drift, drift)
+	}
+
+	sort.Slice(report.Drift, func(i, j int) bool {
+		return report.Drift[i].Attribute < report.Drift[j].Attribute
+	})
+	report.Plan.RequiresReboot = len(report.Plan.Attributes) > 0
+
+	return report
+}
+
+//biosEnumFromBool ... will return the enumeration value a YAML 1.1 boolean was read from
+// true matches On, Yes, True, Y and false Off, No, False, N (case insensitive).
+func biosEnumFromBool(def BiosRegistryAttribute, b bool) string {
+	if def.Type != "Enumeration" && def.Type != "Enum" {
+		return ""
+	}
+
+	words := []string{"off", "no", "false", "n"}
+	if b {
+		words = []string{"on", "yes", "true", "y"}
+	}
+
+	var match string
+	for _, v := range def.Value {
+		for _, w := range words {
+			if strings.ToLower(v.ValueName) == w {
+				if match != "" {
+					return ""
+				}
+				match = v.ValueName
+			}
+		}
+	}
+
+	return match
+}
+
+//biosValueEqual ... numbers compare by value so a YAML int matches a JSON float
+func biosValueEqual(a interface{}, b interface{}) bool {
+	x, xok := toFloat(a)
+	y, yok := toFloat(b)
+	if xok && yok {
+		return x == y
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+//mergeBiosAttributes ... will return current overlaid with changes
+func mergeBiosAttributes(current BiosAttributes, changes map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,301 @@
+package redfishapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//testBiosRegistry ... registry with one attribute of each type and a read-only dependency
+const testBiosRegistry = `{
+	"RegistryEntries": {
+		"Attributes": [
+			{"AttributeName": "BootMode", "Type": "Enumeration", "Value": [{"ValueName": "Uefi"}, {"ValueName": "Bios"}]},
+			{"AttributeName": "AssetTag", "Type": "String", "MaxLength": 5, "ValueExpression": "^[A-Z0-9]*$"},
+			{"AttributeName": "Timeout", "Type": "Integer", "LowerBound": 0, "UpperBound": 60, "ScalarIncrement": 5},
+			{"AttributeName": "Ratio", "Type": "Integer", "LowerBound": 1, "UpperBound": 10, "ScalarIncrement": 0.5},
+			{"AttributeName": "SriovGlobalEnable", "Type": "Boolean"},
+			{"AttributeName": "SystemModel", "Type": "String", "ReadOnly": true},
+			{"Name": "PxeDevice", "Type": "Enum", "Value": [{"ValueName": "Enabled"}, {"ValueName": "Disabled"}]},
+			{"AttributeName": "SerialPort", "Type": "Enumeration", "Value": [{"ValueName": "On"}, {"ValueName": "Off"}]}
+		],
+		"Dependencies": [
+			{
+				"DependencyFor": "PxeDevice",
+				"Type": "Map",
+				"Dependency": {
+					"MapFrom": [
+						{"MapFromAttribute": "BootMode", "MapFromCondition": "EQU", "MapFromProperty": "CurrentValue", "MapFromValue": "Bios"},
+						{"MapFromAttribute": "Timeout", "MapFromCondition": "GTR", "MapFromProperty": "CurrentValue", "MapFromValue": 30, "MapTerms": "OR"}
+					],
+					"MapToAttribute": "PxeDevice",
+					"MapToProperty": "ReadOnly",
+					"MapToValue": true
+				}
+			}
+		]
+	}
+}`
+
+func loadTestBiosRegistry(t *testing.T) BiosRegistry {
+	var reg BiosRegistry
+	if err := json.Unmarshal([]byte(testBiosRegistry), &reg); err != nil {
+		t.Fatal(err)
+	}
+	return reg
+}
+
+func TestValidateBiosAttributes(t *testing.T) {
+	reg := loadTestBiosRegistry(t)
+	current := BiosAttributes{"BootMode": "Uefi", "Timeout": 10.0, "PxeDevice": "Enabled"}
+
+	tests := []struct {
+		name    string
+		changes map[string]interface{}
+		errs    []string // attribute: message fragment
+	}{
+		{"valid enumeration", map[string]interface{}{"BootMode": "Bios"}, nil},
+		{"invalid enumeration", map[string]interface{}{"BootMode": "Legacy"}, []string{"BootMode: is not one of Uefi, Bios"}},
+		{"enumeration needs a string", map[string]interface{}{"BootMode": true}, []string{"BootMode: expected a string"}},
+		{"unknown attribute", map[string]interface{}{"Foo": "Bar"}, []string{"Foo: unknown attribute"}},
+		{"read-only attribute", map[string]interface{}{"SystemModel": "R740"}, []string{"SystemModel: attribute is read-only"}},
+		{"string too long", map[string]interface{}{"AssetTag": "ABCDEF"}, []string{"AssetTag: longer than 5"}},
+		{"string expression", map[string]interface{}{"AssetTag": "ab"}, []string{"AssetTag: does not match"}},
+		{"integer in bounds", map[string]interface{}{"Timeout": 15}, nil},
+		{"integer above bound", map[string]interface{}{"Timeout": 65}, []string{"Timeout: above the upper bound"}},
+		{"integer not a step", map[string]interface{}{"Timeout": 12}, []string{"Timeout: not a multiple of 5"}},
+		{"integer not whole", map[string]interface{}{"Timeout": 12.5}, []string{"Timeout: expected an integer"}},
+		{"fractional increment", map[string]interface{}{"Ratio": 4}, nil},
+		{"boolean", map[string]interface{}{"SriovGlobalEnable": false}, nil},
+		{"boolean needs a bool", map[string]interface{}{"SriovGlobalEnable": "false"}, []string{"SriovGlobalEnable: expected a boolean"}},
+		{"iLO 4 names", map[string]interface{}{"PxeDevice": "Disabled"}, nil},
+		{"dependency from the change", map[string]interface{}{"BootMode": "Bios", "PxeDevice": "Disabled"}, []string{"PxeDevice: read-only while BootMode EQU Bios OR Timeout GTR 30"}},
+		{"dependency OR term", map[string]interface{}{"Timeout": 40, "PxeDevice": "Disabled"}, []string{"PxeDevice: read-only while"}},
+	}
+
+	for _, tt := range tests {
+		err := validateBiosAttributes(reg, current, tt.changes)
+		if len(tt.errs) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+
+		verr, ok := err.(BiosValidationError)
+		if !ok {
+			t.Errorf("%s: got %v, want a BiosValidationError", tt.name, err)
+			continue
+		}
+		if len(verr) != len(tt.errs) {
+			t.Errorf("%s: got %v, want %d errors", tt.name, verr, len(tt.errs))
+			continue
+		}
+		for _, want := range tt.errs {
+			parts := strings.SplitN(want, ": ", 2)
+			found := false
+			for _, e := range verr {
+				if e.Attribute == parts[0] && strings.Contains(e.Message, parts[1]) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%s: got %v, want %q", tt.name, verr, want)
+			}
+		}
+	}
+}
+
+func TestCompareBiosValue(t *testing.T) {
+	tests := []struct {
+		actual    interface{}
+		condition string
+		expected  interface{}
+		want      bool
+	}{
+		{"Bios", "EQU", "Bios", true},
+		{"Uefi", "EQU", "Bios", false},
+		{"Uefi", "NEQ", "Bios", true},
+		{10.0, "EQU", 10, true},
+		{10, "NEQ", 10.0, false},
+		{31.0, "GTR", 30.0, true},
+		{30.0, "GTR", 30.0, false},
+		{30.0, "GEQ", 30.0, true},
+		{29, "LSS", 30.0, true},
+		{30, "LEQ", 30.0, true},
+		{"30", "GTR", 10.0, false},
+		{nil, "EQU", "Bios", false},
+		{"Bios", "XYZ", "Bios", false},
+	}
+
+	for _, tt := range tests {
+		if got := compareBiosValue(tt.actual, tt.condition, tt.expected); got != tt.want {
+			t.Errorf("compareBiosValue(%v, %s, %v) = %v, want %v", tt.actual, tt.condition, tt.expected, got, tt.want)
+		}
+	}
+}
+
+func TestCheckBiosDependencies(t *testing.T) {
+	reg := loadTestBiosRegistry(t)
+
+	tests := []struct {
+		name   string
+		attr   string
+		values map[string]interface{}
+		locked bool
+	}{
+		{"no term matches", "PxeDevice", map[string]interface{}{"BootMode": "Uefi", "Timeout": 10.0}, false},
+		{"first term matches", "PxeDevice", map[string]interface{}{"BootMode": "Bios", "Timeout": 10.0}, true},
+		{"OR term matches", "PxeDevice", map[string]interface{}{"BootMode": "Uefi", "Timeout": 45.0}, true},
+		{"other attribute", "BootMode", map[string]interface{}{"BootMode": "Bios"}, false},
+	}
+
+	for _, tt := range tests {
+		msg := checkBiosDependencies(reg, tt.attr, tt.values)
+		if (msg != "") != tt.locked {
+			t.Errorf("%s: got %q, locked %v", tt.name, msg, tt.locked)
+		}
+	}
+}
+
+func TestCompareBiosBaseline(t *testing.T) {
+	reg := loadTestBiosRegistry(t)
+
+	current := BiosAttributes{
+		"BootMode":    "Uefi",
+		"Timeout":     10.0,
+		"PxeDevice":   "Enabled",
+		"SystemModel": "R740",
+		"SerialPort":  "On",
+	}
+	desired := BiosAttributes{
+		"BootMode":    "Uefi", // in sync
+		"Timeout":     20,     // YAML int against a JSON float
+		"SystemModel": "R640",
+		"SerialPort":  "Maybe",
+		"Missing":     "x",
+	}
+
+	report := compareBiosBaseline(reg, current, desired)
+
+	want := []struct {
+		attr     string
+		settable bool
+		reason   string
+	}{
+		{"Missing", false, "unknown attribute"},
+		{"SerialPort", false, "is not one of On, Off"},
+		{"SystemModel", false, "attribute is read-only"},
+		{"Timeout", true, ""},
+	}
+
+	if len(report.Drift) != len(want) {
+		t.Fatalf("got drift %+v, want %d entries", report.Drift, len(want))
+	}
+	for i, w := range want {
+		d := report.Drift[i]
+		if d.Attribute != w.attr || d.Settable != w.settable || !strings.Contains(d.Reason, w.reason) || (w.reason == "" && d.Reason != "") {
+			t.Errorf("drift %d = %+v, want %+v", i, d, w)
+		}
+	}
+
+	if !reflect.DeepEqual(report.Plan.Attributes, map[string]interface{}{"Timeout": 20}) {
+		t.Errorf("plan = %v", report.Plan.Attributes)
+	}
+	if !report.Plan.RequiresReboot {
+		t.Error("plan with changes does not require a reboot")
+	}
+
+	// a baseline that locks an attribute through a dependency keeps it out of the plan
+	report = compareBiosBaseline(reg, current, BiosAttributes{"BootMode": "Bios", "PxeDevice": "Disabled"})
+	if _, ok := report.Plan.Attributes["PxeDevice"]; ok {
+		t.Errorf("PxeDevice planned while BootMode is Bios: %v", report.Plan.Attributes)
+	}
+	if _, ok := report.Plan.Attributes["BootMode"]; !ok {
+		t.Errorf("BootMode missing from plan: %v", report.Plan.Attributes)
+	}
+}
+
+func TestCompareBiosBaselineYAMLBooleans(t *testing.T) {
+	reg := loadTestBiosRegistry(t)
+
+	dir, err := ioutil.TempDir("", "baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "baseline.yaml")
+	if err := ioutil.WriteFile(path, []byte("Attributes:\n  SerialPort: Off\n  AssetTag: yes\n  SriovGlobalEnable: true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	desired, err := LoadBiosBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := BiosAttributes{"SerialPort": "On", "AssetTag": "", "SriovGlobalEnable": false}
+	report := compareBiosBaseline(reg, current, desired)
+
+	if !reflect.DeepEqual(report.Plan.Attributes, map[string]interface{}{"SerialPort": "Off", "SriovGlobalEnable": true}) {
+		t.Errorf("plan = %v", report.Plan.Attributes)
+	}
+	for _, d := range report.Drift {
+		if d.Attribute == "AssetTag" && (d.Settable || !strings.Contains(d.Reason, "quote")) {
+			t.Errorf("AssetTag drift = %+v", d)
+		}
+	}
+
+	// in sync once the boolean is mapped back to the enumeration value
+	report = compareBiosBaseline(reg, BiosAttributes{"SerialPort": "Off"}, BiosAttributes{"SerialPort": false})
+	if len(report.Drift) != 0 {
+		t.Errorf("drift = %+v", report.Drift)
+	}
+}
+
+func TestAttrIntDecoding(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"number", `{"ProcCoreDisable": 2, "VlanId": 100, "VlanPriority": 3}`},
+		{"string", `{"ProcCoreDisable": "2", "VlanId": "100", "VlanPriority": " 3 "}`},
+	}
+
+	for _, tt := range tests {
+		var hp BiosAttrHP
+		if err := json.Unmarshal([]byte(tt.body), &hp); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if hp.ProcCoreDisable != 2 || hp.VlanID != 100 || hp.VlanPriority != 3 {
+			t.Errorf("%s: got %d %d %d", tt.name, hp.ProcCoreDisable, hp.VlanID, hp.VlanPriority)
+		}
+	}
+
+	var dell BiosAttrDell
+	if err := json.Unmarshal([]byte(`{"Attributes": {"AcPwrRcvryUserDelay": "120"}}`), &dell); err != nil {
+		t.Fatal(err)
+	}
+	if dell.Attributes.AcPwrRcvryUserDelay != 120 {
+		t.Errorf("AcPwrRcvryUserDelay = %d", dell.Attributes.AcPwrRcvryUserDelay)
+	}
+
+	var idrac IDRACAttrDell
+	if err := json.Unmarshal([]byte(`{"Attributes": {"NIC.1.VLanID": "10", "CurrentNIC.1.VLanID": 10, "NIC.1.VLanPriority": null}}`), &idrac); err != nil {
+		t.Fatal(err)
+	}
+	if idrac.Attributes.NIC_1_VLanID != 10 || idrac.Attributes.CurrentNIC_1_VLanID != 10 || idrac.Attributes.NIC_1_VLanPriority != 0 {
+		t.Errorf("iDRAC VLAN attributes = %+v", idrac.Attributes)
+	}
+
+	var n AttrInt
+	if err := json.Unmarshal([]byte(`"Disabled"`), &n); err == nil {
+		t.Error("non numeric string decoded without error")
+	}
+}
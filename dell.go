@@ -1,9 +1,17 @@
 package redfishapi
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +25,49 @@ const (
 	StatusBadRequest          = "Bad Request"
 )
 
+//ResetType values accepted by ResetServerDell and ResetServerHP
+const (
+	ResetOn               = "On"
+	ResetForceOff         = "ForceOff"
+	ResetGracefulShutdown = "GracefulShutdown"
+	ResetGracefulRestart  = "GracefulRestart"
+	ResetForceRestart     = "ForceRestart"
+	ResetPowerCycle       = "PowerCycle"
+	ResetNmi              = "Nmi"
+	ResetPushPowerButton  = "PushPowerButton"
+)
+
+//BootSourceOverrideTarget values accepted by SetBootOverrideDell and SetBootOverrideHP
+const (
+	BootTargetNone       = "None"
+	BootTargetPxe        = "Pxe"
+	BootTargetCd         = "Cd"
+	BootTargetHdd        = "Hdd"
+	BootTargetUefiHttp   = "UefiHttp"
+	BootTargetBiosSetup  = "BiosSetup"
+	BootTargetUefiTarget = "UefiTarget"
+)
+
+//BootSourceOverrideMode values accepted by SetBootOverrideDell and SetBootOverrideHP
+const (
+	BootModeUEFI   = "UEFI"
+	BootModeLegacy = "Legacy"
+)
+
+//BootSourceOverrideEnabled values set by the boot override functions
+const (
+	BootOverrideDisabled   = "Disabled"
+	BootOverrideOnce       = "Once"
+	BootOverrideContinuous = "Continuous"
+)
+
+//Shutdown paths reported by ShutdownDell and ShutdownHP
+const (
+	ShutdownAlreadyOff = "AlreadyOff"
+	ShutdownGraceful   = "GracefulShutdown"
+	ShutdownForced     = "ForceOff"
+)
+
 //StartServerDell ...
 // ResetType@Redfish.AllowableValues
 // 0	"On"
@@ -54,17 +105,34 @@ func (c *IloClient) StopServerDell() (string, error) {
 }
 
 //GracefulRestartDell ... Will Reset Idrac and will take some time to come up
+//
+// Deprecated: it resets the iDRAC, not the server. Use ResetIdracDell, or ResetServerDell
+// with ResetGracefulRestart to restart the server.
 func (c *IloClient) GracefulRestartDell() (string, error) {
-	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Manager.Reset"
+	return c.ResetIdracDell()
+}
 
-	var jsonStr = []byte(`{"ResetType": "GracefulRestart"}`)
-	_, _, _, err := queryData(c, "POST", url, jsonStr)
-	if err != nil {
+//ResetServerDell ... will send a ComputerSystem.Reset of the given type (ResetOn, ResetGracefulRestart ...)
+// The type is checked against the values the system allows.
+func (c *IloClient) ResetServerDell(resetType string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+
+	if err := resetResource(c, url, "ComputerSystem.Reset", resetType); err != nil {
 		return "", err
 	}
 
-	return "Idrac Reset", nil
+	return "Reset Requested", nil
+}
+
+//ResetIdracDell ... will restart the iDRAC, the server keeps running. The iDRAC takes some time to come up
+func (c *IloClient) ResetIdracDell() (string, error) {
+	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1"
+
+	if err := resetResource(c, url, "Manager.Reset", "GracefulRestart"); err != nil {
+		return "", err
+	}
 
+	return "Idrac Reset", nil
 }
 
 //GetServerPowerStateDell ... Will fetch the current state of the Server
@@ -84,6 +152,26 @@ func (c *IloClient) GetServerPowerStateDell() (string, error) {
 
 }
 
+//WaitForPowerStateDell ... will wait until the server reaches the power state ("On", "Off") or ctx is done
+func (c *IloClient) WaitForPowerStateDell(ctx context.Context, state string) error {
+	return waitForPowerState(ctx, c.GetServerPowerStateDell, state)
+}
+
+//ShutdownDell ... will gracefully shut the server down, forcing it off when gracePeriod is over
+// Returns the path taken: ShutdownAlreadyOff, ShutdownGraceful or ShutdownForced.
+func (c *IloClient) ShutdownDell(ctx context.Context, gracePeriod time.Duration) (string, error) {
+	graceful := func() error {
+		_, err := c.ResetServerDell(ResetGracefulShutdown)
+		return err
+	}
+	force := func() error {
+		_, err := c.ResetServerDell(ResetForceOff)
+		return err
+	}
+
+	return shutdownServer(ctx, c.GetServerPowerStateDell, graceful, force, gracePeriod)
+}
+
 //CheckLoginDell ... Will check the credentials of the Server
 // works: R730xd,R740xd
 func (c *IloClient) CheckLoginDell() (string, error) {
@@ -182,6 +270,240 @@ func (c *IloClient) SetBiosSettingsDell(jsonData []byte) (string, error) {
 	return k.MessageExtendedInfo[0].Message, nil
 }
 
+//GetBiosRegistryDell ... will fetch the BIOS attribute registry
+func (c *IloClient) GetBiosRegistryDell() (BiosRegistry, error) {
+	return getBiosRegistry(c, "BiosAttributeRegistry")
+}
+
+//ValidateBiosSettingsDell ... will check BIOS attributes against the registry before they are set
+// Names, types, allowed enum values, bounds, read-only flags and dependencies are checked,
+// every rejected attribute is listed in the returned BiosValidationError.
+func (c *IloClient) ValidateBiosSettingsDell(attrs map[string]interface{}) error {
+	reg, err := c.GetBiosRegistryDell()
+	if err != nil {
+		return err
+	}
+
+	current, err := c.GetBiosAttributesDell()
+	if err != nil {
+		return err
+	}
+
+	return validateBiosAttributes(reg, current, attrs)
+}
+
+//SetBiosAttributesDell ... will validate and stage BIOS attributes
+// A job has to be created (CreateJobDell) to apply them.
+func (c *IloClient) SetBiosAttributesDell(attrs map[string]interface{}) (string, error) {
+	if err := c.ValidateBiosSettingsDell(attrs); err != nil {
+		return "", err
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"Attributes": attrs,
+	})
+
+	return c.SetBiosSettingsDell(data)
+}
+
+//GetBiosPendingDell ... will compare the staged BIOS settings with the active ones
+// The BIOS configuration job that will apply them is returned when one is queued.
+func (c *IloClient) GetBiosPendingDell() (BiosPendingSettings, error) {
+	current, err := c.GetBiosAttributesDell()
+	if err != nil {
+		return BiosPendingSettings{}, err
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios/Settings"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return BiosPendingSettings{}, err
+	}
+
+	var x BiosResourceDell
+
+	json.Unmarshal(resp, &x)
+
+	_result := BiosPendingSettings{Pending: diffBiosAttributes(current, x.Attributes)}
+
+	job, err := c.getBiosJobDell()
+	if err != nil {
+		return _result, err
+	}
+	_result.Job = job
+
+	return _result, nil
+}
+
+//CancelBiosPendingDell ... will drop the staged BIOS settings and their queued configuration job
+// A job that is already running cannot be cancelled.
+func (c *IloClient) CancelBiosPendingDell() (string, error) {
+	job, err := c.getBiosJobDell()
+	if err != nil {
+		return "", err
+	}
+
+	if job != nil {
+		if job.JobState == "Running" {
+			return "", fmt.Errorf("BIOS configuration job %s is already running", job.ID)
+		}
+		_url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/" + job.ID
+		_, _, _, err := queryData(c, "DELETE", _url, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios/Settings/Actions/Oem/DellManager.ClearPending"
+
+	resp, _, status, err := queryData(c, "POST", url, []byte("{}"))
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+
+	return "Pending Settings Cleared", nil
+}
+
+//getBiosJobDell ... will find the BIOS configuration job that has not finished yet
+func (c *IloClient) getBiosJobDell() (*JobStatusDell, error) {
+	jobs, err := c.GetJobsStatusDell()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range jobs {
+		if jobs[i].JobType != "BIOSConfiguration" {
+			continue
+		}
+		switch jobs[i].JobState {
+		case "Completed", "CompletedWithErrors", "Failed":
+			continue
+		}
+		return &jobs[i], nil
+	}
+
+	return nil, nil
+}
+
+//CheckBiosDriftDell ... will compare the live BIOS with a baseline (see LoadBiosBaseline)
+// The report lists every drifted attribute and a plan staging only the settable differences.
+func (c *IloClient) CheckBiosDriftDell(desired BiosAttributes) (BiosDriftReport, error) {
+	reg, err := c.GetBiosRegistryDell()
+	if err != nil {
+		return BiosDriftReport{}, err
+	}
+
+	current, err := c.GetBiosAttributesDell()
+	if err != nil {
+		return BiosDriftReport{}, err
+	}
+
+	report := compareBiosBaseline(reg, current, desired)
+	report.Plan.RequiresJob = len(report.Plan.Attributes) > 0
+
+	return report, nil
+}
+
+//ApplyBiosPlanDell ... will stage the plan attributes and create the BIOS configuration job
+// With reboot the server is gracefully restarted so the job runs right away, otherwise the job
+// stays scheduled until the next reboot. Returns the job location, also when the restart fails.
+func (c *IloClient) ApplyBiosPlanDell(plan BiosApplyPlan, reboot bool) (string, error) {
+	if len(plan.Attributes) == 0 {
+		return "No Changes", nil
+	}
+
+	if _, err := c.SetBiosAttributesDell(plan.Attributes); err != nil {
+		return "", err
+	}
+
+	job, err := c.createBiosJobDell()
+	if err != nil {
+		return "", err
+	}
+
+	if reboot {
+		if _, err := c.ResetServerDell(ResetGracefulRestart); err != nil {
+			return job, fmt.Errorf("job %s stays scheduled until the next reboot, restart failed: %v", job, err)
+		}
+	}
+
+	return job, nil
+}
+
+//createBiosJobDell ... will create the job applying the staged BIOS settings and return its location
+func (c *IloClient) createBiosJobDell() (string, error) {
+	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs"
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"TargetSettingsURI": "/redfish/v1/Systems/System.Embedded.1/Bios/Settings",
+	})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//ResetBiosDell ... will restore the BIOS defaults, they are loaded on the next boot
+// With reboot the server is gracefully restarted right away.
+func (c *IloClient) ResetBiosDell(reboot bool) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios/Actions/Bios.ResetBios"
+
+	resp, _, status, err := queryData(c, "POST", url, []byte(`{}`))
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+
+	if !reboot {
+		return "BIOS Reset Pending Reboot", nil
+	}
+
+	if _, err := c.ResetServerDell(ResetGracefulRestart); err != nil {
+		return "", fmt.Errorf("BIOS reset pending reboot, restart failed: %v", err)
+	}
+
+	return "BIOS Reset", nil
+}
+
+//ChangeBiosPasswordDell ... will set, change or clear (empty newPassword) a BIOS password
+// passwordName is BiosSetupPassword or BiosSystemPassword, oldPassword is empty when none is set.
+// The change is applied by a BIOS configuration job on the next reboot, returns the job location.
+func (c *IloClient) ChangeBiosPasswordDell(passwordName string, oldPassword string, newPassword string) (string, error) {
+	if passwordName != BiosSetupPassword && passwordName != BiosSystemPassword {
+		return "", fmt.Errorf("unsupported BIOS password %q", passwordName)
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios/Actions/Bios.ChangePassword"
+
+	data, _ := json.Marshal(map[string]string{
+		"PasswordName": passwordName,
+		"OldPassword":  oldPassword,
+		"NewPassword":  newPassword,
+	})
+
+	resp, _, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+
+	return c.createBiosJobDell()
+}
+
 //ClearJobsDell ... Deletes all the Jobs in the jobs queue
 func (c *IloClient) ClearJobsDell() (string, error) {
 	url := c.Hostname + "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs"
@@ -333,6 +655,13 @@ func (c *IloClient) GetMacAddressModelDell() ([]MACModelDell, error) {
 
 }
 
+//GetProcessorsDell ... will fetch the installed processors with model, cores, speed and microcode
+func (c *IloClient) GetProcessorsDell() ([]ProcessorData, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Processors"
+
+	return getProcessors(c, url)
+}
+
 //GetProcessorHealthDell ... Will Fetch the Processor Health Details
 // works: R730xd,R740xd
 func (c *IloClient) GetProcessorHealthDell() ([]HealthList, error) {
@@ -374,7 +703,66 @@ func (c *IloClient) GetProcessorHealthDell() ([]HealthList, error) {
 
 }
 
-// func (c *IloClient) GetMemoryHealthDell() (string, error) {}
+//GetMemoryHealthDell ... Will Fetch the Memory Health Details
+func (c *IloClient) GetMemoryHealthDell() ([]HealthList, error) {
+	modules, err := c.GetMemoryDell()
+	if err != nil {
+		return nil, err
+	}
+
+	var memoryHealth []HealthList
+
+	for i := range modules {
+		dimmHealth := HealthList{
+			Name:   modules[i].Slot,
+			Health: modules[i].Health,
+			State:  modules[i].State,
+		}
+		memoryHealth = append(memoryHealth, dimmHealth)
+	}
+
+	return memoryHealth, nil
+}
+
+//GetMemoryDell ... will fetch the installed DIMMs with their health and ECC error status
+func (c *IloClient) GetMemoryDell() ([]MemoryModule, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Memory"
+
+	return getMemoryModules(c, url)
+}
+
+//GetPowerMetricsDell ... will fetch the power consumption, power cap and power supply readings
+func (c *IloClient) GetPowerMetricsDell() (PowerMetrics, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/System.Embedded.1/Power"
+
+	return getPowerMetrics(c, url)
+}
+
+//SetPowerCapDell ... will limit the server power consumption to watts
+func (c *IloClient) SetPowerCapDell(watts int) (string, error) {
+	if watts <= 0 {
+		return "", fmt.Errorf("invalid power cap %d", watts)
+	}
+
+	url := c.Hostname + "/redfish/v1/Chassis/System.Embedded.1/Power"
+
+	if err := setPowerLimit(c, url, watts); err != nil {
+		return "", err
+	}
+
+	return "Power Cap Set", nil
+}
+
+//RemovePowerCapDell ... will remove the power cap
+func (c *IloClient) RemovePowerCapDell() (string, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/System.Embedded.1/Power"
+
+	if err := setPowerLimit(c, url, nil); err != nil {
+		return "", err
+	}
+
+	return "Power Cap Removed", nil
+}
 
 //GetPowerHealthDell ... Will Fetch the Power Health Details
 // works: R730xd,R740xd
@@ -429,6 +817,13 @@ func (c *IloClient) GetPowerHealthDell() ([]HealthList, error) {
 	return powerSupplies, nil
 }
 
+//GetThermalMetricsDell ... will fetch the temperature (Celsius) and fan speed readings with their thresholds
+func (c *IloClient) GetThermalMetricsDell() (ThermalMetrics, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/System.Embedded.1/Thermal"
+
+	return getThermalMetrics(c, url)
+}
+
 //GetSensorsHealthDell ... Will Fetch the Sensors Health Details
 // works: R730xd,R740xd
 func (c *IloClient) GetSensorsHealthDell() ([]HealthList, error) {
@@ -604,198 +999,1240 @@ func (c *IloClient) GetStorageHealthDell() ([]StorageHealthList, error) {
 
 }
 
-//GetAggHealthDataDell ... will fetch the data related to all components health(aggregated view)
-func (c *IloClient) GetAggHealthDataDell(model string) ([]HealthList, error) {
+//volumeTypesDell ... VolumeType used by iDRAC firmware that predates RAIDType
+// RAID6 and RAID60 have no VolumeType of their own, they would come back as RAID5 and RAID50.
+var volumeTypesDell = map[string]string{
+	"RAID0":  "NonRedundant",
+	"RAID1":  "Mirrored",
+	"RAID5":  "StripedWithParity",
+	"RAID6":  "StripedWithParity",
+	"RAID10": "SpannedMirrors",
+	"RAID50": "SpannedStripesWithParity",
+	"RAID60": "SpannedStripesWithParity",
+}
 
-	if strings.ToLower(model) == "r730xd" {
+//GetVolumesDell ... will fetch the virtual disks of a RAID controller (RAID.Integrated.1-1)
+func (c *IloClient) GetVolumesDell(controller string) ([]VolumeData, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage/" + controller + "/Volumes"
 
-		return nil, nil
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	} else if strings.ToLower(model) == "r740xd" {
-		url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+	var (
+		x        MemberCountDell
+		_volumes []VolumeData
+	)
 
-		resp, _, _, err := queryData(c, "GET", url, nil)
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+x.Members[i].OdataId, nil)
 		if err != nil {
 			return nil, err
 		}
 
-		var (
-			x           MemberCountDell
-			_healthdata []HealthList
-		)
+		var v VolumeResource
 
-		json.Unmarshal(resp, &x)
+		json.Unmarshal(resp, &v)
 
-		for i := range x.Members {
-			r, _ := regexp.Compile("Installed")
-			if r.MatchString(x.Members[i].OdataId) == true {
-				_url := c.Hostname + x.Members[i].OdataId
-				resp, _, _, err := queryData(c, "GET", _url, nil)
-				if err != nil {
-					return nil, err
-				}
+		_result := VolumeData{
+			ID:               v.ID,
+			Name:             v.Name,
+			RAIDType:         v.RAIDType,
+			CapacityBytes:    v.CapacityBytes,
+			StripeSizeBytes:  v.OptimumIOSizeBytes,
+			ReadCachePolicy:  v.ReadCachePolicy,
+			WriteCachePolicy: v.WriteCachePolicy,
+			Health:           v.Status.Health,
+			State:            v.Status.State,
+		}
+		if _result.RAIDType == "" {
+			_result.RAIDType = v.VolumeType
+		}
+		for _, d := range v.Links.Drives {
+			_result.Drives = append(_result.Drives, path.Base(d.OdataId))
+		}
 
-				var y FirmwareDataDell
+		_volumes = append(_volumes, _result)
+	}
 
-				json.Unmarshal(resp, &y)
+	return _volumes, nil
+}
 
-				healthData := HealthList{
-					Name:   y.Name,
-					State:  y.Status.State,
-					Health: y.Status.Health,
-				}
+//CreateVolumeDell ... will create a virtual disk on a RAID controller (RAID.Integrated.1-1)
+// The volume is created right away by a realtime job, returns the job location (see WaitForJobDell).
+func (c *IloClient) CreateVolumeDell(controller string, params VolumeCreateParams) (string, error) {
+	if len(params.Drives) == 0 {
+		return "", errors.New("no drives given for the volume")
+	}
+	if _, ok := volumeTypesDell[params.RAIDType]; !ok {
+		return "", fmt.Errorf("unsupported RAID type %q", params.RAIDType)
+	}
 
-				_healthdata = append(_healthdata, healthData)
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage/" + controller + "/Volumes"
 
-			}
+	var drives []map[string]string
+	for _, d := range params.Drives {
+		if !strings.HasPrefix(d, "/redfish/") {
+			d = "/redfish/v1/Systems/System.Embedded.1/Storage/Drives/" + d
 		}
-
-		return _healthdata, nil
+		drives = append(drives, map[string]string{"@odata.id": d})
 	}
-	return nil, nil
-}
 
-//GetFirmwareDell ... will fetch the Firmware details
-func (c *IloClient) GetFirmwareDell() ([]FirmwareData, error) {
-
-	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+	payload := map[string]interface{}{
+		"RAIDType":                    params.RAIDType,
+		"Links":                       map[string]interface{}{"Drives": drives},
+		"@Redfish.OperationApplyTime": "Immediate",
+	}
+	if params.Name != "" {
+		payload["Name"] = params.Name
+	}
+	if params.SizeBytes > 0 {
+		payload["CapacityBytes"] = params.SizeBytes
+	}
+	if params.StripeSizeBytes > 0 {
+		payload["OptimumIOSizeBytes"] = params.StripeSizeBytes
+	}
+	if params.ReadCachePolicy != "" {
+		payload["ReadCachePolicy"] = params.ReadCachePolicy
+	}
+	if params.WriteCachePolicy != "" {
+		payload["WriteCachePolicy"] = params.WriteCachePolicy
+	}
+
+	data, _ := json.Marshal(payload)
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil && err.Error() == StatusBadRequest && raidTypeUnknownDell(resp) {
+		// older firmware only knows VolumeType, which cannot tell RAID6/60 from RAID5/50
+		if params.RAIDType == "RAID6" || params.RAIDType == "RAID60" {
+			return "", fmt.Errorf("%s needs iDRAC firmware supporting RAIDType", params.RAIDType)
+		}
+		delete(payload, "RAIDType")
+		payload["VolumeType"] = volumeTypesDell[params.RAIDType]
+		data, _ = json.Marshal(payload)
+		resp, header, status, err = queryData(c, "POST", url, data)
+	}
+	if err != nil && err.Error() == StatusBadRequest {
+		return "", redfishError(resp, status)
+	}
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//raidTypeUnknownDell ... will tell if a failed volume creation was rejected because RAIDType is not supported
+func raidTypeUnknownDell(body []byte) bool {
+	var x RedfishErrorResponse
+
+	json.Unmarshal(body, &x)
+
+	for _, m := range x.Error.MessageExtendedInfo {
+		if !strings.Contains(m.MessageID, "PropertyUnknown") {
+			continue
+		}
+		for _, p := range append(m.MessageArgs, m.RelatedProperties...) {
+			if strings.Contains(p, "RAIDType") {
+				return true
+			}
+		}
+		if strings.Contains(m.Message, "RAIDType") {
+			return true
+		}
+	}
+
+	return false
+}
+
+//DeleteVolumeDell ... will delete a virtual disk (Disk.Virtual.0:RAID.Integrated.1-1), returns the job location
+func (c *IloClient) DeleteVolumeDell(volume string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage/Volumes/" + volume
+
+	resp, header, status, err := queryData(c, "DELETE", url, nil)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//InitializeVolumeDell ... will initialize a virtual disk, initType is "Fast" or "Slow"
+// Returns the job location.
+func (c *IloClient) InitializeVolumeDell(volume string, initType string) (string, error) {
+	if initType != "Fast" && initType != "Slow" {
+		return "", fmt.Errorf("unsupported initialize type %q", initType)
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage/Volumes/" + volume + "/Actions/Volume.Initialize"
+
+	data, _ := json.Marshal(map[string]string{"InitializeType": initType})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//AssignSpareDell ... will make a drive a hot spare of the given virtual disks, a global hot spare when none are given
+// Uses the DellRaidService OEM action, returns the job location.
+func (c *IloClient) AssignSpareDell(drive string, volumes []string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellRaidService/Actions/DellRaidService.AssignSpare"
+
+	payload := map[string]interface{}{"TargetFQDD": drive}
+	if len(volumes) > 0 {
+		payload["VirtualDiskArray"] = volumes
+	}
+
+	data, _ := json.Marshal(payload)
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//PlanDriveEraseDell ... will list the drives an erase would wipe without touching them
+// drives are drive IDs (Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1) and may not be empty,
+// use PlanAllDrivesEraseDell to select every drive. Self-encrypting drives are cryptographically
+// erased, drives without an erase action, in a virtual disk or assigned as hot spare are listed
+// as not supported.
+func (c *IloClient) PlanDriveEraseDell(drives []string) (DriveErasePlan, error) {
+	if len(drives) == 0 {
+		return DriveErasePlan{}, errors.New("no drives given, use PlanAllDrivesEraseDell to erase every drive")
+	}
+
+	return planDriveEraseDell(c, drives)
+}
+
+//PlanAllDrivesEraseDell ... will list every drive of the system for an erase, boot drive included
+func (c *IloClient) PlanAllDrivesEraseDell() (DriveErasePlan, error) {
+	return planDriveEraseDell(c, nil)
+}
+
+func planDriveEraseDell(c *IloClient, drives []string) (DriveErasePlan, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage"
+
+	found, err := getStorageDrives(c, url)
+	if err != nil {
+		return DriveErasePlan{}, err
+	}
+
+	wanted := make(map[string]bool)
+	for _, d := range drives {
+		wanted[d] = true
+	}
+
+	plan := DriveErasePlan{
+		PollInterval: 30 * time.Second,
+		Timeout:      4 * time.Hour,
+	}
+
+	for _, f := range found {
+		if drives != nil && !wanted[f.drive.ID] {
+			continue
+		}
+		delete(wanted, f.drive.ID)
+
+		target := DriveEraseTarget{
+			ID:            f.drive.ID,
+			Controller:    f.controller,
+			Model:         strings.TrimSpace(f.drive.Model),
+			SerialNumber:  strings.TrimSpace(f.drive.SerialNumber),
+			CapacityBytes: f.drive.CapacityBytes,
+			Method:        "SecureErase",
+			Supported:     true,
+		}
+		if f.drive.EncryptionAbility == "SelfEncryptingDrive" {
+			target.Method = "CryptographicErase"
+		}
+
+		switch {
+		case len(f.drive.Links.Volumes) > 0 || f.drive.Oem.Dell.DellPhysicalDisk.RaidStatus == "Online":
+			target.Supported = false
+			target.Reason = "drive is part of a virtual disk"
+		case f.drive.HotspareType != "" && f.drive.HotspareType != "None":
+			target.Supported = false
+			target.Reason = "drive is assigned as " + f.drive.HotspareType + " hot spare"
+		case target.Method == "SecureErase" && f.drive.Actions.DriveSecureErase.Target == "":
+			target.Supported = false
+			target.Reason = "drive does not support Drive.SecureErase"
+		}
+
+		plan.Drives = append(plan.Drives, target)
+	}
+
+	if len(wanted) > 0 {
+		var missing []string
+		for d := range wanted {
+			missing = append(missing, d)
+		}
+		sort.Strings(missing)
+		return plan, fmt.Errorf("drives not found: %s", strings.Join(missing, ", "))
+	}
+
+	return plan, nil
+}
+
+//ExecuteDriveErasePlanDell ... will erase the supported drives of a plan one after the other
+// Each erase job is waited on, a failed drive does not stop the others. A zero PollInterval
+// or Timeout gets the PlanDriveEraseDell defaults (30s, 4h).
+func (c *IloClient) ExecuteDriveErasePlanDell(plan DriveErasePlan) ([]DriveEraseResult, error) {
+	if plan.PollInterval <= 0 {
+		plan.PollInterval = 30 * time.Second
+	}
+	if plan.Timeout <= 0 {
+		plan.Timeout = 4 * time.Hour
+	}
+
+	var _results []DriveEraseResult
+
+	for _, d := range plan.Drives {
+		if !d.Supported {
+			continue
+		}
+
+		_result := DriveEraseResult{ID: d.ID}
+
+		var job string
+		var err error
+		if d.Method == "CryptographicErase" {
+			job, err = c.CryptoEraseDriveDell(d.ID)
+		} else {
+			job, err = c.SecureEraseDriveDell(d.ID)
+		}
+		if err != nil {
+			_result.Message = err.Error()
+			_results = append(_results, _result)
+			continue
+		}
+		_result.TaskURL = job
+
+		status, err := c.WaitForJobDell(job, plan.PollInterval, plan.Timeout)
+		_result.JobState = status.JobState
+		_result.Message = status.Message
+		if err != nil {
+			_result.Message = err.Error()
+		}
+		_result.Success = err == nil
+
+		_results = append(_results, _result)
+	}
+
+	return _results, nil
+}
+
+//SecureEraseDriveDell ... will erase a drive with Drive.SecureErase
+// Returns the job location.
+func (c *IloClient) SecureEraseDriveDell(drive string) (string, error) {
+	found, err := getStorageDrives(c, c.Hostname+"/redfish/v1/Systems/System.Embedded.1/Storage")
+	if err != nil {
+		return "", err
+	}
+
+	var url string
+	for _, f := range found {
+		if f.drive.ID == drive && f.drive.Actions.DriveSecureErase.Target != "" {
+			url = c.Hostname + f.drive.Actions.DriveSecureErase.Target
+		}
+	}
+	if url == "" {
+		return "", fmt.Errorf("drive %q not found or does not support Drive.SecureErase", drive)
+	}
+
+	resp, header, status, err := queryData(c, "POST", url, []byte(`{}`))
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//CryptoEraseDriveDell ... will cryptographically erase a self-encrypting drive
+// Uses the DellRaidService OEM action, returns the job location.
+func (c *IloClient) CryptoEraseDriveDell(drive string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellRaidService/Actions/DellRaidService.SecureErase"
+
+	data, _ := json.Marshal(map[string]string{"TargetFQDD": drive})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//ResetConfigDell ... will reset a PERC controller configuration (RAID.Integrated.1-1)
+// Every virtual disk is deleted and the hot spares are unassigned. Returns the job location.
+func (c *IloClient) ResetConfigDell(controller string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Oem/Dell/DellRaidService/Actions/DellRaidService.ResetConfig"
+
+	data, _ := json.Marshal(map[string]string{"TargetFQDD": controller})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//WaitForJobDell ... will poll a job (location or JID) until it completes or fails
+func (c *IloClient) WaitForJobDell(job string, interval time.Duration, timeout time.Duration) (JobStatusDell, error) {
+	if interval <= 0 || timeout <= 0 {
+		return JobStatusDell{}, fmt.Errorf("interval and timeout must be positive, got %v and %v", interval, timeout)
+	}
+
+	job = strings.TrimPrefix(job, c.Hostname)
+	if !strings.HasPrefix(job, "/") {
+		job = "/redfish/v1/Managers/iDRAC.Embedded.1/Jobs/" + job
+	}
+
+	status, err := c.waitForJobDell(job, interval, timeout, "Completed", "CompletedWithErrors", "Failed")
+	if err != nil {
+		return status, err
+	}
+	if status.JobState == "Failed" {
+		return status, fmt.Errorf("job %s failed: %s", path.Base(job), status.Message)
+	}
+
+	return status, nil
+}
+
+//GetDriveHealthDell ... will fetch the wear, failure prediction and hot spare status of every drive
+// NVMe drives report their remaining write endurance through the Dell OEM data.
+func (c *IloClient) GetDriveHealthDell() ([]DriveHealth, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage"
+
+	found, err := getStorageDrives(c, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var _drives []DriveHealth
+	for _, f := range found {
+		_drives = append(_drives, driveHealth(f))
+	}
+
+	return _drives, nil
+}
+
+//GetStorageControllersDell ... will fetch the cache, battery, write cache and foreign configuration state of the RAID controllers
+func (c *IloClient) GetStorageControllersDell() ([]StorageControllerStatus, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Storage"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x            MemberCountDell
+		_controllers []StorageControllerStatus
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+x.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y StorageResource
+
+		json.Unmarshal(resp, &y)
+
+		if len(y.StorageControllers) == 0 {
+			continue
+		}
+
+		_result := StorageControllerStatus{
+			ID:            y.ID,
+			Model:         y.StorageControllers[0].Model,
+			Firmware:      y.StorageControllers[0].FirmwareVersion,
+			Health:        y.Status.Health,
+			State:         y.Status.State,
+			CacheSizeMiB:  y.StorageControllers[0].CacheSummary.TotalCacheSizeMiB,
+			CacheHealth:   y.StorageControllers[0].CacheSummary.Status.Health,
+			BatteryHealth: y.Oem.Dell.DellControllerBattery.PrimaryStatus,
+			BatteryState:  y.Oem.Dell.DellControllerBattery.RAIDState,
+		}
+		if _result.Firmware == "" {
+			_result.Firmware = y.Oem.Dell.DellController.ControllerFirmwareVersion
+		}
+		if _result.CacheSizeMiB == 0 {
+			_result.CacheSizeMiB = y.Oem.Dell.DellController.CacheSizeInMB
+		}
+		_result.CacheDegraded = (_result.BatteryHealth != "" && _result.BatteryHealth != "OK") ||
+			(_result.CacheHealth != "" && _result.CacheHealth != "OK")
+
+		// a drive holding a foreign configuration is reported with the Foreign RAID status
+		for k := range y.Drives {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+y.Drives[k].OdataId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var z DriveResource
+
+			json.Unmarshal(resp, &z)
+
+			if z.Oem.Dell.DellPhysicalDisk.RaidStatus == "Foreign" {
+				_result.ForeignConfig = true
+			}
+		}
+
+		if y.Volumes.OdataId != "" {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+y.Volumes.OdataId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var v MemberCountDell
+
+			json.Unmarshal(resp, &v)
+
+			for k := range v.Members {
+				resp, _, _, err := queryData(c, "GET", c.Hostname+v.Members[k].OdataId, nil)
+				if err != nil {
+					return nil, err
+				}
+
+				var vol VolumeResource
+
+				json.Unmarshal(resp, &vol)
+
+				if vol.WriteCachePolicy == "" {
+					continue
+				}
+				mode := "WriteThrough"
+				if strings.Contains(vol.WriteCachePolicy, "WriteBack") {
+					mode = "WriteBack"
+				}
+				if _result.WriteCache != "" && _result.WriteCache != mode {
+					mode = "Mixed"
+				}
+				_result.WriteCache = mode
+			}
+		}
+
+		_controllers = append(_controllers, _result)
+	}
+
+	return _controllers, nil
+}
+
+//GetAggHealthDataDell ... will fetch the data related to all components health(aggregated view)
+func (c *IloClient) GetAggHealthDataDell(model string) ([]HealthList, error) {
+
+	if strings.ToLower(model) == "r730xd" {
+
+		return nil, nil
+
+	} else if strings.ToLower(model) == "r740xd" {
+		url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+
+		resp, _, _, err := queryData(c, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			x           MemberCountDell
+			_healthdata []HealthList
+		)
+
+		json.Unmarshal(resp, &x)
+
+		for i := range x.Members {
+			r, _ := regexp.Compile("Installed")
+			if r.MatchString(x.Members[i].OdataId) == true {
+				_url := c.Hostname + x.Members[i].OdataId
+				resp, _, _, err := queryData(c, "GET", _url, nil)
+				if err != nil {
+					return nil, err
+				}
+
+				var y FirmwareDataDell
+
+				json.Unmarshal(resp, &y)
+
+				healthData := HealthList{
+					Name:   y.Name,
+					State:  y.Status.State,
+					Health: y.Status.Health,
+				}
+
+				_healthdata = append(_healthdata, healthData)
+
+			}
+		}
+
+		return _healthdata, nil
+	}
+	return nil, nil
+}
+
+//GetFirmwareDell ... will fetch the Firmware details
+func (c *IloClient) GetFirmwareDell() ([]FirmwareData, error) {
+
+	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x         MemberCountDell
+		_firmdata []FirmwareData
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+
+		_url := c.Hostname + x.Members[i].OdataId
+		resp, _, _, err := queryData(c, "GET", _url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y FirmwareDataDell
+
+		json.Unmarshal(resp, &y)
+
+		firmData := FirmwareData{
+			Name:       y.Name,
+			Id:         y.ID,
+			Version:    y.Version,
+			Updateable: y.Updateable,
+		}
+
+		_firmdata = append(_firmdata, firmData)
+
+	}
+
+	return _firmdata, nil
+
+}
+
+//FirmwareUpdateDell ... will create a job plan for firmware update
+func (c *IloClient) FirmwareUpdateDell() (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		x         MemberCountDell
+		firmLinks []string
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		r, _ := regexp.Compile("Available")
+		if r.MatchString(x.Members[i].OdataId) == true {
+
+			firmLinks = append(firmLinks, x.Members[i].OdataId)
+
+		}
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"SoftwareIdentityURIs": firmLinks,
+		"InstallUpon":          "NowAndReboot",
+	})
+
+	firmUrl := c.Hostname + "/redfish/v1/UpdateService/Actions/Oem/DellUpdateService.Install"
+	_, header, _, errr := queryData(c, "POST", firmUrl, []byte(data))
+	if errr != nil {
+		return "", err
+	}
+
+	var taskURL string
+
+	for k, v := range header {
+		if k == "Location" {
+			taskURL = v[0]
+			break
+		}
+	}
+
+	if len(firmLinks) == 0 {
+		return "No Firmware Available", nil
+	} else {
+		return taskURL, nil
+	}
+
+}
+
+//FirmwareUploadDell ... will fetch the payload from remote repo
+func (c *IloClient) FirmwareUploadDell(repoUrl string) (string, error) {
+
+	url := c.Hostname + "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"ImageURI": repoUrl,
+	})
+
+	_, headers, _, err := queryData(c, "POST", url, []byte(data))
+	if err != nil {
+		return "", err
+	}
+
+	return headers["Location"][0], nil
+}
+
+func (c *IloClient) TaskStatusDell(taskUrl string) (ExportConfigStatus, error) {
+	url := c.Hostname + taskUrl
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return ExportConfigStatus{}, err
+	}
+
+	var x ExportConfigStatus
+
+	json.Unmarshal(resp, &x)
+
+	return x, nil
+
+}
+
+// Install timing values for DellUpdateService.Install
+const (
+	InstallUponNow          = "Now"
+	InstallUponNextReboot   = "NextReboot"
+	InstallUponNowAndReboot = "NowAndReboot"
+)
+
+//PlanFirmwareUpdateDell ... will build an ordered update plan from the staged (Available) firmware
+// components can hold the Id, Name or ComponentID of the entries, an empty list selects all of them.
+// The plan is ordered iDRAC first, then BIOS, then the remaining devices and can be reordered before execution.
+// A maintenance window is only honoured together with InstallUponNextReboot.
+func (c *IloClient) PlanFirmwareUpdateDell(components []string, installUpon string, window *MaintenanceWindow) (FirmwareUpdatePlanDell, error) {
+
+	switch installUpon {
+	case InstallUponNow, InstallUponNextReboot, InstallUponNowAndReboot:
+	default:
+		return FirmwareUpdatePlanDell{}, fmt.Errorf("invalid InstallUpon value %q", installUpon)
+	}
+	if window != nil && installUpon != InstallUponNextReboot {
+		return FirmwareUpdatePlanDell{}, fmt.Errorf("maintenance window requires InstallUpon %q", InstallUponNextReboot)
+	}
+
+	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return FirmwareUpdatePlanDell{}, err
+	}
+
+	var (
+		x       MemberCountDell
+		matched = make(map[string]bool)
+		plan    = FirmwareUpdatePlanDell{InstallUpon: installUpon, MaintenanceWindow: window}
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		if !strings.Contains(x.Members[i].OdataId, "Available") {
+			continue
+		}
+
+		_url := c.Hostname + x.Members[i].OdataId
+		resp, _, _, err := queryData(c, "GET", _url, nil)
+		if err != nil {
+			return FirmwareUpdatePlanDell{}, err
+		}
+
+		var y FirmwareDataDell
+
+		json.Unmarshal(resp, &y)
+
+		comp := FirmwareUpdateComponentDell{
+			ID:                  y.ID,
+			Name:                y.Name,
+			Version:             y.Version,
+			ComponentID:         y.Oem.Dell.DellSoftwareInventory.ComponentID,
+			SoftwareIdentityURI: x.Members[i].OdataId,
+		}
+
+		if len(components) == 0 {
+			plan.Components = append(plan.Components, comp)
+			continue
+		}
+		for _, want := range components {
+			if want == comp.ID || want == comp.Name || (comp.ComponentID != "" && want == comp.ComponentID) {
+				plan.Components = append(plan.Components, comp)
+				matched[want] = true
+				break
+			}
+		}
+	}
+
+	for _, want := range components {
+		if !matched[want] {
+			return FirmwareUpdatePlanDell{}, fmt.Errorf("firmware component %q is not staged", want)
+		}
+	}
+
+	sort.SliceStable(plan.Components, func(i, j int) bool {
+		return firmwareUpdateRankDell(plan.Components[i].Name) < firmwareUpdateRankDell(plan.Components[j].Name)
+	})
+
+	return plan, nil
+}
+
+//firmwareUpdateRankDell ... iDRAC has to be updated before BIOS and BIOS before the devices
+func firmwareUpdateRankDell(name string) int {
+	n := strings.ToLower(name)
+	switch {
+	case strings.Contains(n, "idrac") || strings.Contains(n, "remote access controller"):
+		return 0
+	case strings.Contains(n, "bios"):
+		return 1
+	}
+	return 2
+}
+
+//ExecuteFirmwareUpdatePlanDell ... will install the plan components one at a time and track each task
+// A failed component does not stop the remaining ones, the outcome is reported per component.
+// Components waiting for a reboot (all but the iDRAC with InstallUponNow) are reported once Scheduled.
+// With InstallUponNowAndReboot they are scheduled and the host is restarted once after the last install.
+func (c *IloClient) ExecuteFirmwareUpdatePlanDell(plan FirmwareUpdatePlanDell) ([]FirmwareUpdateResult, error) {
+
+	interval, timeout := plan.PollInterval, plan.Timeout
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+	if timeout == 0 {
+		timeout = time.Hour
+	}
+
+	var (
+		results []FirmwareUpdateResult
+		jobIDs  []string
+		pending []int // results waiting for the single reboot of a NowAndReboot plan
+	)
+
+	for _, comp := range plan.Components {
+		result := FirmwareUpdateResult{
+			ID:      comp.ID,
+			Name:    comp.Name,
+			Version: comp.Version,
+		}
+
+		// NowAndReboot would restart the host once per component, the reboot-bound ones are
+		// scheduled for the next reboot instead and the host is restarted once at the end
+		rebootBound := firmwareUpdateRankDell(comp.Name) > 0
+		installUpon := plan.InstallUpon
+		if installUpon == InstallUponNowAndReboot {
+			installUpon = InstallUponNow
+			if rebootBound {
+				installUpon = InstallUponNextReboot
+			}
+		}
+
+		taskURL, err := c.InstallFirmwareDell([]string{comp.SoftwareIdentityURI}, installUpon)
+		if err != nil {
+			result.Message = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.TaskURL = taskURL
+
+		// Jobs installed on the next reboot stop at Scheduled until the host restarts,
+		// with Now only the iDRAC is updated right away, BIOS and devices wait for a reboot
+		scheduled := installUpon == InstallUponNextReboot || (installUpon == InstallUponNow && rebootBound)
+
+		doneStates := []string{"Completed", "CompletedWithErrors", "Failed"}
+		if scheduled {
+			doneStates = append(doneStates, "Scheduled")
+		}
+
+		job, err := c.waitForJobDell(taskURL, interval, timeout, doneStates...)
+		result.JobState = job.JobState
+		result.Message = job.Message
+		if err != nil {
+			result.Message = err.Error()
+		}
+		result.Success = job.JobState == "Completed" || (job.JobState == "Scheduled" && scheduled)
+		if result.Success {
+			_id := strings.Split(taskURL, "/")
+			jobIDs = append(jobIDs, _id[len(_id)-1])
+		}
+		if plan.InstallUpon == InstallUponNowAndReboot && job.JobState == "Scheduled" {
+			pending = append(pending, len(results))
+		}
+		results = append(results, result)
+	}
+
+	if plan.MaintenanceWindow != nil && len(jobIDs) > 0 {
+		if err := c.scheduleJobsDell(jobIDs, *plan.MaintenanceWindow); err != nil {
+			return results, err
+		}
+	}
+
+	if len(pending) > 0 {
+		if _, err := c.ResetServerDell(ResetGracefulRestart); err != nil {
+			return results, fmt.Errorf("firmware jobs stay scheduled until the next reboot, restart failed: %v", err)
+		}
 
-	resp, _, _, err := queryData(c, "GET", url, nil)
+		for _, i := range pending {
+			job, err := c.waitForJobDell(results[i].TaskURL, interval, timeout, "Completed", "CompletedWithErrors", "Failed")
+			results[i].JobState = job.JobState
+			results[i].Message = job.Message
+			if err != nil {
+				results[i].Message = err.Error()
+			}
+			results[i].Success = job.JobState == "Completed"
+		}
+	}
+
+	return results, nil
+}
+
+//InstallFirmwareDell ... will install staged firmware packages and return the task location
+// uris are SoftwareIdentity locations such as the one returned by UploadFirmwareDell.
+func (c *IloClient) InstallFirmwareDell(uris []string, installUpon string) (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/Actions/Oem/DellUpdateService.Install"
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"SoftwareIdentityURIs": uris,
+		"InstallUpon":          installUpon,
+	})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil && err.Error() == StatusBadRequest {
+		return "", redfishError(resp, status)
+	}
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	var (
-		x         MemberCountDell
-		_firmdata []FirmwareData
-	)
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
 
-	json.Unmarshal(resp, &x)
+	return header.Get("Location"), nil
+}
 
-	for i := range x.Members {
+//scheduleJobsDell ... will restrict the given jobs to run inside the maintenance window
+func (c *IloClient) scheduleJobsDell(jobIDs []string, window MaintenanceWindow) error {
+	url := c.Hostname + "/redfish/v1/Dell/Managers/iDRAC.Embedded.1/DellJobService/Actions/DellJobService.SetupJobQueue"
 
-		_url := c.Hostname + x.Members[i].OdataId
-		resp, _, _, err := queryData(c, "GET", _url, nil)
-		if err != nil {
-			return nil, err
+	data, _ := json.Marshal(map[string]interface{}{
+		"JobArray":          jobIDs,
+		"StartTimeInterval": window.Start.Format("20060102150405"),
+		"UntilTime":         window.Start.Add(window.Duration).Format("20060102150405"),
+	})
+
+	resp, _, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return redfishError(resp, status)
+	}
+
+	return nil
+}
+
+//waitForJobDell ... will poll a task or job until it reaches one of the given states
+// The iDRAC being unreachable (e.g. while it resets after its own update) is retried until the timeout.
+func (c *IloClient) waitForJobDell(taskURL string, interval time.Duration, timeout time.Duration, states ...string) (JobStatusDell, error) {
+	url := c.Hostname + taskURL
+	deadline := time.Now().Add(timeout)
+
+	var job JobStatusDell
+
+	for {
+		resp, _, _, err := queryData(c, "GET", url, nil)
+		if err != nil && err.Error() != StatusInternalServerError {
+			return job, err
 		}
 
-		var y FirmwareDataDell
+		if err == nil {
+			var x ExportConfigStatus
 
-		json.Unmarshal(resp, &y)
+			json.Unmarshal(resp, &job)
+			json.Unmarshal(resp, &x)
 
-		firmData := FirmwareData{
-			Name:       y.Name,
-			Id:         y.ID,
-			Version:    y.Version,
-			Updateable: y.Updateable,
+			// Task resources carry the job details in the Dell OEM section
+			if job.JobState == "" {
+				job.ID = x.Oem.Dell.ID
+				job.JobState = x.Oem.Dell.JobState
+				job.JobType = x.Oem.Dell.JobType
+				job.Message = x.Oem.Dell.Message
+				job.MessageID = x.Oem.Dell.MessageID
+				job.PercentComplete = x.Oem.Dell.PercentComplete
+			}
+
+			for _, state := range states {
+				if job.JobState == state {
+					return job, nil
+				}
+			}
 		}
 
-		_firmdata = append(_firmdata, firmData)
+		if time.Now().After(deadline) {
+			return job, fmt.Errorf("timed out waiting for %s, last state %q", taskURL, job.JobState)
+		}
+		time.Sleep(interval)
+	}
+}
 
+//UploadFirmwareDell ... will push a firmware image to the iDRAC over HTTP and stage it
+// size is the length of r (-1 if unknown), progress may be nil.
+// The returned location is the Available entry which can be installed with InstallFirmwareDell.
+func (c *IloClient) UploadFirmwareDell(fileName string, r io.Reader, size int64, progress UploadProgress) (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return "", err
 	}
 
-	return _firmdata, nil
+	var x UpdateServiceDell
+
+	json.Unmarshal(resp, &x)
+
+	if x.HTTPPushURI == "" {
+		x.HTTPPushURI = "/redfish/v1/UpdateService/FirmwareInventory"
+	}
+
+	// The iDRAC rejects the push unless it carries the current ETag of the inventory
+	_, header, _, err := queryData(c, "GET", c.Hostname+x.HTTPPushURI, nil)
+	if err != nil {
+		return "", err
+	}
+	headers := map[string]string{"If-Match": header.Get("ETag")}
+
+	resp, header, status, err := uploadData(c, c.Hostname+x.HTTPPushURI, nil, "file", fileName, r, size, headers, progress)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
 
+	return header.Get("Location"), nil
 }
 
-//FirmwareUpdateDell ... will create a job plan for firmware update
-func (c *IloClient) FirmwareUpdateDell() (string, error) {
-	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+//UploadFirmwareFileDell ... will push a local firmware file to the iDRAC and stage it
+func (c *IloClient) UploadFirmwareFileDell(path string, progress UploadProgress) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	return c.UploadFirmwareDell(filepath.Base(path), f, info.Size(), progress)
+}
+
+//MultipartUploadFirmwareDell ... will upload and install an image in one step through MultipartHttpPushUri
+// Supported from 4.40 Firmware, returns the task location of the update.
+func (c *IloClient) MultipartUploadFirmwareDell(fileName string, r io.Reader, size int64, params FirmwareUpdateParameters, progress UploadProgress) (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService"
 
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
 
+	var x UpdateServiceDell
+
+	json.Unmarshal(resp, &x)
+
+	if x.MultipartHTTPPushURI == "" {
+		return "", errors.New("MultipartHttpPushUri is not supported by this iDRAC")
+	}
+
+	return multipartPushUpdate(c, x.MultipartHTTPPushURI, fileName, r, size, params, progress)
+}
+
+//GetRollbackFirmwareDell ... will fetch the components that keep a previous image to roll back to
+func (c *IloClient) GetRollbackFirmwareDell() ([]RollbackFirmware, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		x         MemberCountDell
-		firmLinks []string
+		installed = make(map[string]string)
+		_rollback []RollbackFirmware
 	)
 
 	json.Unmarshal(resp, &x)
 
 	for i := range x.Members {
-		r, _ := regexp.Compile("Available")
-		if r.MatchString(x.Members[i].OdataId) == true {
+		if !strings.Contains(x.Members[i].OdataId, "Installed") && !strings.Contains(x.Members[i].OdataId, "Previous") {
+			continue
+		}
 
-			firmLinks = append(firmLinks, x.Members[i].OdataId)
+		_url := c.Hostname + x.Members[i].OdataId
+		resp, _, _, err := queryData(c, "GET", _url, nil)
+		if err != nil {
+			return nil, err
+		}
 
+		var y FirmwareDataDell
+
+		json.Unmarshal(resp, &y)
+
+		if strings.HasPrefix(y.ID, "Previous") {
+			_result := RollbackFirmware{
+				ID:              y.ID,
+				Name:            y.Name,
+				RollbackVersion: y.Version,
+				RollbackURI:     x.Members[i].OdataId,
+			}
+			_rollback = append(_rollback, _result)
+		} else {
+			installed[firmwareComponentDell(y.ID)] = y.Version
 		}
 	}
 
-	data, _ := json.Marshal(map[string]interface{}{
-		"SoftwareIdentityURIs": firmLinks,
-		"InstallUpon":          "NowAndReboot",
-	})
-
-	firmUrl := c.Hostname + "/redfish/v1/UpdateService/Actions/Oem/DellUpdateService.Install"
-	_, header, _, errr := queryData(c, "POST", firmUrl, []byte(data))
-	if errr != nil {
-		return "", err
+	for i := range _rollback {
+		_rollback[i].InstalledVersion = installed[firmwareComponentDell(_rollback[i].ID)]
 	}
 
-	var taskURL string
+	return _rollback, nil
+}
 
-	for k, v := range header {
-		if k == "Location" {
-			taskURL = v[0]
-			break
-		}
+//firmwareComponentDell ... will strip the state and version from an inventory Id
+// e.g. "Previous-159-1.2.3__BIOS.Setup.1-1" becomes "159__BIOS.Setup.1-1"
+func firmwareComponentDell(id string) string {
+	parts := strings.SplitN(id, "-", 3)
+	if len(parts) < 3 {
+		return id
 	}
+	component := parts[1]
+	if i := strings.Index(parts[2], "__"); i >= 0 {
+		component += parts[2][i:]
+	}
+	return component
+}
 
-	if len(firmLinks) == 0 {
-		return "No Firmware Available", nil
-	} else {
-		return taskURL, nil
+//RollbackFirmwareDell ... will install the previous image of a component and track the task
+// component can be the Id or Name of an entry from GetRollbackFirmwareDell.
+// BIOS and device rollbacks stay Scheduled until the next reboot.
+func (c *IloClient) RollbackFirmwareDell(component string, interval time.Duration, timeout time.Duration) (FirmwareUpdateResult, error) {
+	rollbacks, err := c.GetRollbackFirmwareDell()
+	if err != nil {
+		return FirmwareUpdateResult{}, err
 	}
 
-}
+	for _, rb := range rollbacks {
+		if rb.ID != component && rb.Name != component {
+			continue
+		}
 
-//FirmwareUploadDell ... will fetch the payload from remote repo
-func (c *IloClient) FirmwareUploadDell(repoUrl string) (string, error) {
+		result := FirmwareUpdateResult{
+			ID:      rb.ID,
+			Name:    rb.Name,
+			Version: rb.RollbackVersion,
+		}
 
-	url := c.Hostname + "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+		url := c.Hostname + "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+		data, _ := json.Marshal(map[string]interface{}{
+			"ImageURI": rb.RollbackURI,
+		})
 
-	data, _ := json.Marshal(map[string]interface{}{
-		"ImageURI": repoUrl,
-	})
+		resp, header, status, err := queryData(c, "POST", url, data)
+		if err != nil {
+			return result, err
+		}
+		if header.Get("Location") == "" {
+			return result, redfishError(resp, status)
+		}
+		result.TaskURL = header.Get("Location")
 
-	_, headers, _, err := queryData(c, "POST", url, []byte(data))
-	if err != nil {
-		return "", err
+		job, err := c.waitForJobDell(result.TaskURL, interval, timeout, "Completed", "CompletedWithErrors", "Failed", "Scheduled")
+		result.JobState = job.JobState
+		result.Message = job.Message
+		result.Success = job.JobState == "Completed" || job.JobState == "Scheduled"
+
+		return result, err
 	}
 
-	return headers["Location"][0], nil
+	return FirmwareUpdateResult{}, fmt.Errorf("no rollback image for %q", component)
 }
 
-func (c *IloClient) TaskStatusDell(taskUrl string) (ExportConfigStatus, error) {
-	url := c.Hostname + taskUrl
+//GetBiosDataDell ... will fetch the Bios Details
+func (c *IloClient) GetBiosDataDell() (BiosAttributesData, error) {
+
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios"
 
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
-		return ExportConfigStatus{}, err
+		return BiosAttributesData{}, err
 	}
 
-	var x ExportConfigStatus
+	var x BiosAttrDell
 
 	json.Unmarshal(resp, &x)
 
-	return x, nil
+	return x.Attributes, nil
 
 }
 
-//GetBiosDataDell ... will fetch the Bios Details
-func (c *IloClient) GetBiosDataDell() (BiosAttributesData, error) {
+//GetBiosAttributesDell ... will fetch every BIOS attribute as a map
+// Unlike GetBiosDataDell no attribute is dropped and values keep the type the firmware returns.
+func (c *IloClient) GetBiosAttributesDell() (BiosAttributes, error) {
 
 	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1/Bios"
 
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
-		return BiosAttributesData{}, err
+		return nil, err
 	}
 
-	var x BiosAttrDell
+	var x BiosResourceDell
 
 	json.Unmarshal(resp, &x)
 
@@ -1009,6 +2446,208 @@ func (c *IloClient) SetBootOrderDell(jsonData []byte) (string, error) {
 
 }
 
+//SetBootOverrideDell ... will set the boot source override of the server
+// target is one of the BootSourceOverrideTarget allowable values (BootTargetPxe, BootTargetHdd ... BootTargetNone),
+// mode is BootModeUEFI or BootModeLegacy (empty keeps the current one), once limits the override to the next boot.
+func (c *IloClient) SetBootOverrideDell(target string, mode string, once bool) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+
+	return setBootOverride(c, url, target, mode, "", once)
+}
+
+//SetUefiTargetBootOverrideDell ... will boot the server from a UEFI device path
+func (c *IloClient) SetUefiTargetBootOverrideDell(uefiTarget string, once bool) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+
+	return setBootOverride(c, url, BootTargetUefiTarget, "", uefiTarget, once)
+}
+
+//GetBootOptionsDell ... will fetch the boot devices in persistent boot order from the BootOptions collection
+func (c *IloClient) GetBootOptionsDell() ([]BootOrderData, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+
+	options, _, err := getBootOptions(c, url)
+
+	return options, err
+}
+
+//ReorderBootOptionsDell ... will move the given boot devices (ID or name) to the front of the boot order
+// The other devices keep their relative order, devices missing from Boot.BootOrder cannot be
+// added since the iDRAC only accepts a permutation of it. The change is staged with a job that
+// runs on the next reboot, returns the job location.
+func (c *IloClient) ReorderBootOptionsDell(devices []string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+
+	boot, err := getSystemBoot(c, url)
+	if err != nil {
+		return "", err
+	}
+
+	options, _, err := listBootOptions(c, boot)
+	if err != nil {
+		return "", err
+	}
+
+	ordered := make(map[string]bool)
+	for _, ref := range boot.BootOrder {
+		ordered[ref] = true
+	}
+
+	var current []BootOrderData
+	for _, o := range options {
+		if ordered[o.ID] {
+			current = append(current, o)
+		}
+	}
+	for _, d := range devices {
+		o, err := findBootOption(options, d)
+		if err == nil && !ordered[o.ID] {
+			return "", fmt.Errorf("boot device %q is not in the boot order", d)
+		}
+	}
+
+	order, err := reorderBootOptions(current, devices)
+	if err != nil {
+		return "", err
+	}
+
+	url += "/Settings"
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"Boot":                       map[string]interface{}{"BootOrder": order},
+		"@Redfish.SettingsApplyTime": map[string]string{"ApplyTime": "OnReset"},
+	})
+
+	resp, header, status, err := queryData(c, "PATCH", url, data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+	if header.Get("Location") != "" {
+		return header.Get("Location"), nil
+	}
+
+	return "Boot Order Pending Reboot", nil
+}
+
+//SetBootOptionEnabledDell ... will enable or disable a boot device (ID or name)
+func (c *IloClient) SetBootOptionEnabledDell(device string, enabled bool) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/System.Embedded.1"
+
+	return setBootOptionEnabled(c, url, device, enabled)
+}
+
+//SetHTTPBootDell ... will configure UEFI HTTP boot device 1-4 through the HttpDev BIOS attributes
+// The settings are applied by a BIOS configuration job on the next reboot, returns the job location.
+func (c *IloClient) SetHTTPBootDell(device int, cfg HTTPBootConfig) (string, error) {
+	prefix := fmt.Sprintf("HttpDev%d", device)
+
+	attrs := map[string]interface{}{
+		prefix + "EnDis":        "Enabled",
+		prefix + "Uri":          cfg.URI,
+		prefix + "DhcpEnDis":    "Enabled",
+		prefix + "DnsDhcpEnDis": "Enabled",
+	}
+	if cfg.Interface != "" {
+		attrs[prefix+"Interface"] = cfg.Interface
+	}
+	if cfg.IPMode != "" {
+		attrs[prefix+"Protocol"] = cfg.IPMode
+	}
+	if cfg.Address != "" {
+		attrs[prefix+"DhcpEnDis"] = "Disabled"
+		attrs[prefix+"Ip"] = cfg.Address
+		attrs[prefix+"Mask"] = cfg.Netmask
+		attrs[prefix+"Gateway"] = cfg.Gateway
+	}
+	if cfg.DNS != "" {
+		attrs[prefix+"DnsDhcpEnDis"] = "Disabled"
+		attrs[prefix+"Dns1"] = cfg.DNS
+	}
+
+	if _, err := c.SetBiosAttributesDell(attrs); err != nil {
+		return "", err
+	}
+
+	return c.createBiosJobDell()
+}
+
+//SetISCSIBootDell ... will configure iSCSI device 1 connection 1-2 through the IscsiDev1 BIOS attributes
+// cfg.Interface is the NIC FQDD. The settings are applied by a BIOS configuration job
+// on the next reboot, returns the job location.
+func (c *IloClient) SetISCSIBootDell(connection int, cfg ISCSIBootConfig) (string, error) {
+	if err := checkISCSIBootConfig(cfg); err != nil {
+		return "", err
+	}
+
+	prefix := fmt.Sprintf("IscsiDev1Con%d", connection)
+
+	attrs := map[string]interface{}{
+		"IscsiDev1EnDis":        "Enabled",
+		prefix + "EnDis":        "Enabled",
+		prefix + "Protocol":     iscsiAddressType(cfg),
+		prefix + "DhcpEnDis":    "Enabled",
+		prefix + "TgtDhcpEnDis": "Enabled",
+		prefix + "Auth":         "None",
+	}
+	if cfg.InitiatorName != "" {
+		attrs["IscsiInitiatorName"] = cfg.InitiatorName
+	}
+	if cfg.Interface != "" {
+		attrs[prefix+"Interface"] = cfg.Interface
+	}
+	if cfg.InitiatorAddress != "" {
+		attrs[prefix+"DhcpEnDis"] = "Disabled"
+		attrs[prefix+"Ip"] = cfg.InitiatorAddress
+		attrs[prefix+"Mask"] = cfg.InitiatorNetmask
+		attrs[prefix+"Gateway"] = cfg.InitiatorGateway
+	}
+	if cfg.TargetName != "" {
+		port := cfg.TargetPort
+		if port == 0 {
+			port = 3260
+		}
+		attrs[prefix+"TgtDhcpEnDis"] = "Disabled"
+		attrs[prefix+"TargetName"] = cfg.TargetName
+		attrs[prefix+"TargetIp"] = cfg.TargetAddress
+		attrs[prefix+"Port"] = port
+		attrs[prefix+"Lun"] = strconv.Itoa(cfg.LUN)
+	}
+	switch cfg.AuthenticationMethod {
+	case "CHAP":
+		attrs[prefix+"Auth"] = "Chap"
+		attrs[prefix+"ChapType"] = "OneWay"
+	case "MutualCHAP":
+		attrs[prefix+"Auth"] = "Chap"
+		attrs[prefix+"ChapType"] = "Mutual"
+		attrs[prefix+"MutualChapName"] = cfg.MutualCHAPUsername
+		attrs[prefix+"MutualChapSecret"] = cfg.MutualCHAPSecret
+	}
+	if cfg.CHAPUsername != "" {
+		attrs[prefix+"ChapName"] = cfg.CHAPUsername
+		attrs[prefix+"ChapSecret"] = cfg.CHAPSecret
+	}
+
+	if _, err := c.SetBiosAttributesDell(attrs); err != nil {
+		return "", err
+	}
+
+	return c.createBiosJobDell()
+}
+
+//SetNetworkISCSIBootDell ... will configure iSCSI boot on a NIC port through its NetworkDeviceFunction
+// function is the port FQDD (NIC.Integrated.1-1-1). The settings are applied on the next
+// reboot, returns the job location.
+func (c *IloClient) SetNetworkISCSIBootDell(function string, cfg ISCSIBootConfig) (string, error) {
+	adapter := strings.SplitN(function, "-", 2)[0]
+
+	url := c.Hostname + "/redfish/v1/Chassis/System.Embedded.1/NetworkAdapters/" + adapter + "/NetworkDeviceFunctions/" + function
+
+	return setISCSIBoot(c, url, cfg, "OnReset")
+}
+
 //GetSystemEventLogsDell ... Fetch the System Event Logs from the Idrac
 func (c *IloClient) GetSystemEventLogsDell(version string) ([]SystemEventLogRes, error) {
 
@@ -5,4 +5,5 @@ go 1.12
 require (
 	github.com/Jeffail/gabs v1.4.0
 	github.com/hashicorp/go-version v1.2.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -2,12 +2,19 @@ package redfishapi
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -18,6 +25,8 @@ func basicAuth(username, password string) string {
 }
 
 //queryData ... will make REST verbs based on the url
+// A 401 returns a nil body with StatusUnauthorized, a 400 returns the Redfish error body with
+// StatusBadRequest so callers can inspect @Message.ExtendedInfo. Other statuses are not errors.
 func queryData(c *IloClient, call string, link string, data []byte) ([]byte, http.Header, int, error) {
 	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	req, err := http.NewRequest(call, link, bytes.NewBuffer(data))
@@ -41,8 +50,11 @@ func queryData(c *IloClient, call string, link string, data []byte) ([]byte, htt
 			err := errors.New(StatusUnauthorized)
 			return nil, resp.Header, resp.StatusCode, err
 		} else if resp.StatusCode == 400 {
+			// the body carries the Redfish error details
+			defer resp.Body.Close()
+			_body, _ := ioutil.ReadAll(resp.Body)
 			err := errors.New(StatusBadRequest)
-			return nil, resp.Header, resp.StatusCode, err
+			return _body, resp.Header, resp.StatusCode, err
 		}
 
 	}
@@ -55,3 +67,1039 @@ func queryData(c *IloClient, call string, link string, data []byte) ([]byte, htt
 
 	return _body, resp.Header, resp.StatusCode, nil
 }
+
+//redfishError ... will build an error from the Redfish error body of a failed request
+func redfishError(body []byte, status int) error {
+	var x RedfishErrorResponse
+	json.Unmarshal(body, &x)
+	if len(x.Error.MessageExtendedInfo) > 0 {
+		return fmt.Errorf("%d: %s", status, x.Error.MessageExtendedInfo[0].Message)
+	}
+	if x.Error.Message != "" {
+		return fmt.Errorf("%d: %s", status, x.Error.Message)
+	}
+	return fmt.Errorf("request failed with status %d", status)
+}
+
+//formPart ... a multipart form field sent ahead of the uploaded file
+type formPart struct {
+	name        string
+	contentType string
+	data        []byte
+}
+
+//progressReader ... reports the bytes read through it to the progress callback
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress UploadProgress
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.sent += int64(n)
+	if n > 0 && p.progress != nil {
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+//uploadData ... will POST a multipart form with the file streamed from r as the last part
+// size is the length of r, when it is negative the body is sent chunked.
+func uploadData(c *IloClient, link string, parts []formPart, fileField string, fileName string, r io.Reader, size int64, headers map[string]string, progress UploadProgress) ([]byte, http.Header, int, error) {
+	if strings.ContainsAny(fileName, "\r\n") {
+		return nil, nil, 0, fmt.Errorf("file name %q contains a line break", fileName)
+	}
+
+	var head bytes.Buffer
+	mw := multipart.NewWriter(&head)
+	for _, part := range parts {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(part.name)))
+		if part.contentType != "" {
+			h.Set("Content-Type", part.contentType)
+		}
+		w, err := mw.CreatePart(h)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		w.Write(part.data)
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(fileField), quoteEscaper.Replace(fileName)))
+	h.Set("Content-Type", "application/octet-stream")
+	if _, err := mw.CreatePart(h); err != nil {
+		return nil, nil, 0, err
+	}
+	tail := []byte("\r\n--" + mw.Boundary() + "--\r\n")
+
+	body := io.MultiReader(&head, &progressReader{r: r, total: size, progress: progress}, bytes.NewReader(tail))
+
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	req, err := http.NewRequest("POST", link, body)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if size >= 0 {
+		req.ContentLength = int64(head.Len()) + size + int64(len(tail))
+	}
+	req.Header.Add("Authorization", "Basic "+basicAuth(c.Username, c.Password))
+	req.Header.Add("Accept", "application/json")
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	client := &http.Client{
+		Timeout: time.Minute * 30,
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 {
+		return nil, resp.Header, resp.StatusCode, errors.New(StatusUnauthorized)
+	}
+
+	_body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.Header, resp.StatusCode, err
+	}
+
+	return _body, resp.Header, resp.StatusCode, nil
+}
+
+//quoteEscaper ... escapes a Content-Disposition parameter the way mime/multipart does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+//multipartPushUpdate ... will upload an image to a MultipartHttpPushUri and return the task location
+func multipartPushUpdate(c *IloClient, pushURI string, fileName string, r io.Reader, size int64, params FirmwareUpdateParameters, progress UploadProgress) (string, error) {
+	data, _ := json.Marshal(params)
+	parts := []formPart{{name: "UpdateParameters", contentType: "application/json", data: data}}
+
+	resp, header, status, err := uploadData(c, c.Hostname+pushURI, parts, "UpdateFile", fileName, r, size, nil, progress)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//createSession ... will open a Redfish session and return its token and location
+func createSession(c *IloClient) (string, string, error) {
+	url := c.Hostname + "/redfish/v1/SessionService/Sessions/"
+	data, _ := json.Marshal(map[string]interface{}{
+		"UserName": c.Username,
+		"Password": c.Password,
+	})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", "", err
+	}
+	if header.Get("X-Auth-Token") == "" {
+		return "", "", redfishError(resp, status)
+	}
+
+	return header.Get("X-Auth-Token"), header.Get("Location"), nil
+}
+
+//deleteSession ... will close a session opened by createSession
+func deleteSession(c *IloClient, location string) error {
+	if !strings.HasPrefix(location, "http") {
+		location = c.Hostname + location
+	}
+	_, _, _, err := queryData(c, "DELETE", location, nil)
+	return err
+}
+
+//checkAllowable ... will make sure value is one of the allowable values, an empty list allows anything
+func checkAllowable(property string, value string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, a := range allowed {
+		if a == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %q is not one of %s", property, value, strings.Join(allowed, ", "))
+}
+
+//setBootOverride ... will PATCH the boot source override of a ComputerSystem
+// The target, mode and UEFI target are checked against the values the system allows,
+// target BootTargetNone turns the override off.
+func setBootOverride(c *IloClient, systemURL string, target string, mode string, uefiTarget string, once bool) (string, error) {
+	resp, _, _, err := queryData(c, "GET", systemURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var x struct {
+		Boot SystemBoot `json:"Boot"`
+	}
+
+	json.Unmarshal(resp, &x)
+
+	allowed := x.Boot.BootSourceOverrideTargetAllowableValues
+	if len(allowed) == 0 {
+		allowed = x.Boot.BootSourceOverrideSupported
+	}
+	if err := checkAllowable("BootSourceOverrideTarget", target, allowed); err != nil {
+		return "", err
+	}
+
+	boot := map[string]interface{}{
+		"BootSourceOverrideTarget":  target,
+		"BootSourceOverrideEnabled": BootOverrideContinuous,
+	}
+	if once {
+		boot["BootSourceOverrideEnabled"] = BootOverrideOnce
+	}
+	if target == BootTargetNone {
+		boot["BootSourceOverrideEnabled"] = BootOverrideDisabled
+	}
+	if mode != "" {
+		if err := checkAllowable("BootSourceOverrideMode", mode, x.Boot.BootSourceOverrideModeAllowableValues); err != nil {
+			return "", err
+		}
+		boot["BootSourceOverrideMode"] = mode
+	}
+	if uefiTarget != "" {
+		allowed := x.Boot.UefiTargetBootSourceOverrideAllowableValues
+		if len(allowed) == 0 {
+			allowed = x.Boot.UefiTargetBootSourceOverrideSupported
+		}
+		if err := checkAllowable("UefiTargetBootSourceOverride", uefiTarget, allowed); err != nil {
+			return "", err
+		}
+		boot["UefiTargetBootSourceOverride"] = uefiTarget
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"Boot": boot})
+
+	resp, _, status, err := queryData(c, "PATCH", systemURL, data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+
+	return "Boot Override Set", nil
+}
+
+//getBootOptions ... will list the BootOptions of a ComputerSystem in boot order
+// ID is the BootOptionReference used in Boot.BootOrder, options missing from the order are listed last.
+func getBootOptions(c *IloClient, systemURL string) ([]BootOrderData, map[string]string, error) {
+	boot, err := getSystemBoot(c, systemURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return listBootOptions(c, boot)
+}
+
+//getSystemBoot ... will fetch the Boot property of a ComputerSystem
+func getSystemBoot(c *IloClient, systemURL string) (SystemBoot, error) {
+	resp, _, _, err := queryData(c, "GET", systemURL, nil)
+	if err != nil {
+		return SystemBoot{}, err
+	}
+
+	var x struct {
+		Boot SystemBoot `json:"Boot"`
+	}
+
+	json.Unmarshal(resp, &x)
+
+	return x.Boot, nil
+}
+
+//listBootOptions ... will list the BootOptions of a Boot property in its boot order
+func listBootOptions(c *IloClient, boot SystemBoot) ([]BootOrderData, map[string]string, error) {
+	if boot.BootOptions.OdataID == "" {
+		return nil, nil, errors.New("system has no BootOptions collection")
+	}
+
+	resp, _, _, err := queryData(c, "GET", c.Hostname+boot.BootOptions.OdataID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var members MemberCountDell
+
+	json.Unmarshal(resp, &members)
+
+	var (
+		options = make(map[string]BootOrderData)
+		links   = make(map[string]string)
+		refs    []string
+	)
+
+	for i := range members.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+members.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var o BootOption
+
+		json.Unmarshal(resp, &o)
+
+		ref := o.BootOptionReference
+		if ref == "" {
+			ref = o.ID
+		}
+		name := o.DisplayName
+		if name == "" {
+			name = o.Name
+		}
+
+		options[ref] = BootOrderData{
+			Enabled: o.BootOptionEnabled == nil || *o.BootOptionEnabled,
+			Name:    name,
+			ID:      ref,
+		}
+		links[ref] = members.Members[i].OdataId
+		refs = append(refs, ref)
+	}
+
+	var _bootOrder []BootOrderData
+
+	for _, ref := range boot.BootOrder {
+		if o, ok := options[ref]; ok {
+			o.Index = len(_bootOrder)
+			_bootOrder = append(_bootOrder, o)
+			delete(options, ref)
+		}
+	}
+	for _, ref := range refs {
+		if o, ok := options[ref]; ok {
+			o.Index = len(_bootOrder)
+			_bootOrder = append(_bootOrder, o)
+		}
+	}
+
+	return _bootOrder, links, nil
+}
+
+//findBootOption ... will match a boot device by ID or (case insensitive) name
+func findBootOption(options []BootOrderData, device string) (BootOrderData, error) {
+	for _, o := range options {
+		if o.ID == device {
+			return o, nil
+		}
+	}
+	for _, o := range options {
+		if strings.EqualFold(o.ID, device) || strings.EqualFold(o.Name, device) {
+			return o, nil
+		}
+	}
+	return BootOrderData{}, fmt.Errorf("boot device %q not found", device)
+}
+
+//reorderBootOptions ... will move the given devices to the front, the others keep their relative order
+func reorderBootOptions(options []BootOrderData, devices []string) ([]string, error) {
+	var (
+		order []string
+		moved = make(map[string]bool)
+	)
+
+	for _, d := range devices {
+		o, err := findBootOption(options, d)
+		if err != nil {
+			return nil, err
+		}
+		if moved[o.ID] {
+			return nil, fmt.Errorf("boot device %q listed twice", d)
+		}
+		moved[o.ID] = true
+		order = append(order, o.ID)
+	}
+	for _, o := range options {
+		if !moved[o.ID] {
+			order = append(order, o.ID)
+		}
+	}
+
+	return order, nil
+}
+
+//setBootOptionEnabled ... will enable or disable a boot device through BootOptionEnabled
+func setBootOptionEnabled(c *IloClient, systemURL string, device string, enabled bool) (string, error) {
+	options, links, err := getBootOptions(c, systemURL)
+	if err != nil {
+		return "", err
+	}
+
+	o, err := findBootOption(options, device)
+	if err != nil {
+		return "", err
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"BootOptionEnabled": enabled})
+
+	resp, _, status, err := queryData(c, "PATCH", c.Hostname+links[o.ID], data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+
+	if enabled {
+		return "Boot Device Enabled", nil
+	}
+	return "Boot Device Disabled", nil
+}
+
+//checkISCSIBootConfig ... will reject an address type or authentication the settings cannot describe
+func checkISCSIBootConfig(cfg ISCSIBootConfig) error {
+	if err := checkAllowable("IPAddressType", iscsiAddressType(cfg), []string{"IPv4", "IPv6"}); err != nil {
+		return err
+	}
+
+	switch cfg.AuthenticationMethod {
+	case "", "None":
+	case "CHAP":
+		if cfg.CHAPUsername == "" || cfg.CHAPSecret == "" {
+			return errors.New("CHAP needs CHAPUsername and CHAPSecret")
+		}
+	case "MutualCHAP":
+		if cfg.CHAPUsername == "" || cfg.CHAPSecret == "" || cfg.MutualCHAPUsername == "" || cfg.MutualCHAPSecret == "" {
+			return errors.New("MutualCHAP needs CHAPUsername, CHAPSecret, MutualCHAPUsername and MutualCHAPSecret")
+		}
+	default:
+		return fmt.Errorf("AuthenticationMethod %q is not one of None, CHAP, MutualCHAP", cfg.AuthenticationMethod)
+	}
+
+	return nil
+}
+
+//iscsiAddressType ... IPv4 unless the config asks for IPv6
+func iscsiAddressType(cfg ISCSIBootConfig) string {
+	if cfg.IPAddressType == "" {
+		return "IPv4"
+	}
+	return cfg.IPAddressType
+}
+
+//iscsiBootPayload ... will build the iSCSIBoot property of a NetworkDeviceFunction
+func iscsiBootPayload(cfg ISCSIBootConfig) map[string]interface{} {
+	boot := map[string]interface{}{
+		"IPAddressType":     iscsiAddressType(cfg),
+		"InitiatorName":     cfg.InitiatorName,
+		"IPMaskDNSViaDHCP":  cfg.InitiatorAddress == "",
+		"TargetInfoViaDHCP": cfg.TargetName == "",
+	}
+	if cfg.InitiatorAddress != "" {
+		boot["InitiatorIPAddress"] = cfg.InitiatorAddress
+		boot["InitiatorNetmask"] = cfg.InitiatorNetmask
+		boot["InitiatorDefaultGateway"] = cfg.InitiatorGateway
+	}
+	if cfg.TargetName != "" {
+		port := cfg.TargetPort
+		if port == 0 {
+			port = 3260
+		}
+		boot["PrimaryTargetName"] = cfg.TargetName
+		boot["PrimaryTargetIPAddress"] = cfg.TargetAddress
+		boot["PrimaryTargetTCPPort"] = port
+		boot["PrimaryLUN"] = cfg.LUN
+	}
+	if cfg.AuthenticationMethod != "" {
+		boot["AuthenticationMethod"] = cfg.AuthenticationMethod
+	}
+	if cfg.CHAPUsername != "" {
+		boot["CHAPUsername"] = cfg.CHAPUsername
+		boot["CHAPSecret"] = cfg.CHAPSecret
+	}
+	if cfg.AuthenticationMethod == "MutualCHAP" {
+		boot["MutualCHAPUsername"] = cfg.MutualCHAPUsername
+		boot["MutualCHAPSecret"] = cfg.MutualCHAPSecret
+	}
+	return boot
+}
+
+//setISCSIBoot ... will PATCH the iSCSIBoot settings of a NetworkDeviceFunction
+// Uses the @Redfish.Settings object when the function has one, applyTime is sent with it when set.
+// Returns the Location header (a job on Dell).
+func setISCSIBoot(c *IloClient, functionURL string, cfg ISCSIBootConfig, applyTime string) (string, error) {
+	if err := checkISCSIBootConfig(cfg); err != nil {
+		return "", err
+	}
+
+	resp, _, _, err := queryData(c, "GET", functionURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var x struct {
+		RedfishSettings struct {
+			SettingsObject struct {
+				OdataID string `json:"@odata.id"`
+			} `json:"SettingsObject"`
+		} `json:"@Redfish.Settings"`
+	}
+
+	json.Unmarshal(resp, &x)
+
+	url := functionURL
+	payload := map[string]interface{}{
+		"NetDevFuncType": "iSCSI",
+		"iSCSIBoot":      iscsiBootPayload(cfg),
+	}
+	if x.RedfishSettings.SettingsObject.OdataID != "" {
+		url = c.Hostname + x.RedfishSettings.SettingsObject.OdataID
+	}
+	if applyTime != "" {
+		payload["@Redfish.SettingsApplyTime"] = map[string]string{"ApplyTime": applyTime}
+	}
+
+	data, _ := json.Marshal(payload)
+
+	resp, header, status, err := queryData(c, "PATCH", url, data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//resetResource ... will POST a Reset action (ComputerSystem.Reset, Manager.Reset) of a resource
+// resetType is checked against ResetType@Redfish.AllowableValues, iLO 4 lists them in AvailableActions.
+func resetResource(c *IloClient, resourceURL string, action string, resetType string) error {
+	resp, _, _, err := queryData(c, "GET", resourceURL, nil)
+	if err != nil {
+		return err
+	}
+
+	var x struct {
+		Actions map[string]struct {
+			Target     string   `json:"target"`
+			ResetTypes []string `json:"ResetType@Redfish.AllowableValues"`
+		} `json:"Actions"`
+		AvailableActions []struct {
+			Action       string `json:"Action"`
+			Capabilities []struct {
+				AllowableValues []string `json:"AllowableValues"`
+				PropertyName    string   `json:"PropertyName"`
+			} `json:"Capabilities"`
+		} `json:"AvailableActions"`
+	}
+
+	json.Unmarshal(resp, &x)
+
+	a := x.Actions["#"+action]
+	allowed := a.ResetTypes
+	for _, av := range x.AvailableActions {
+		for _, cp := range av.Capabilities {
+			if len(allowed) == 0 && av.Action == "Reset" && cp.PropertyName == "ResetType" {
+				allowed = cp.AllowableValues
+			}
+		}
+	}
+	if err := checkAllowable("ResetType", resetType, allowed); err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(resourceURL, "/") + "/Actions/" + action + "/"
+	if a.Target != "" {
+		url = c.Hostname + a.Target
+	}
+
+	data, _ := json.Marshal(map[string]string{"ResetType": resetType})
+
+	resp, _, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return redfishError(resp, status)
+	}
+
+	return nil
+}
+
+//waitForPowerState ... will poll the power state until it matches state or ctx is done
+// Errors from an unreachable BMC are retried.
+func waitForPowerState(ctx context.Context, getState func() (string, error), state string) error {
+	for {
+		current, err := getState()
+		if err == nil && strings.EqualFold(current, state) {
+			return nil
+		}
+		if err != nil && err.Error() != StatusInternalServerError {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+//shutdownServer ... will try a graceful shutdown and force the power off once gracePeriod is over
+func shutdownServer(ctx context.Context, getState func() (string, error), graceful func() error, force func() error, gracePeriod time.Duration) (string, error) {
+	state, err := getState()
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(state, "Off") {
+		return ShutdownAlreadyOff, nil
+	}
+
+	if err := graceful(); err != nil {
+		return "", err
+	}
+
+	gctx, cancel := context.WithTimeout(ctx, gracePeriod)
+	err = waitForPowerState(gctx, getState, "Off")
+	cancel()
+	if err == nil {
+		return ShutdownGraceful, nil
+	}
+	if ctx.Err() != nil || err != context.DeadlineExceeded {
+		return "", err
+	}
+
+	if err := force(); err != nil {
+		return "", err
+	}
+	if err := waitForPowerState(ctx, getState, "Off"); err != nil {
+		return "", err
+	}
+
+	return ShutdownForced, nil
+}
+
+//getPowerMetrics ... will read the consumption and power supply readings of a chassis Power resource
+func getPowerMetrics(c *IloClient, powerURL string) (PowerMetrics, error) {
+	resp, _, _, err := queryData(c, "GET", powerURL, nil)
+	if err != nil {
+		return PowerMetrics{}, err
+	}
+
+	var x PowerResource
+
+	json.Unmarshal(resp, &x)
+
+	_result := PowerMetrics{
+		ConsumedWatts:        x.PowerConsumedWatts,
+		CapacityWatts:        x.PowerCapacityWatts,
+		MinConsumedWatts:     x.PowerMetrics.MinConsumedWatts,
+		MaxConsumedWatts:     x.PowerMetrics.MaxConsumedWatts,
+		AverageConsumedWatts: x.PowerMetrics.AverageConsumedWatts,
+		IntervalInMin:        x.PowerMetrics.IntervalInMin,
+	}
+	if x.PowerLimit.LimitInWatts != nil {
+		_result.LimitInWatts = *x.PowerLimit.LimitInWatts
+	}
+
+	if len(x.PowerControl) > 0 {
+		pc := x.PowerControl[0]
+		_result.ConsumedWatts = pc.PowerConsumedWatts
+		_result.CapacityWatts = pc.PowerCapacityWatts
+		_result.MinConsumedWatts = pc.PowerMetrics.MinConsumedWatts
+		_result.MaxConsumedWatts = pc.PowerMetrics.MaxConsumedWatts
+		_result.AverageConsumedWatts = pc.PowerMetrics.AverageConsumedWatts
+		_result.IntervalInMin = pc.PowerMetrics.IntervalInMin
+		if pc.PowerLimit.LimitInWatts != nil {
+			_result.LimitInWatts = *pc.PowerLimit.LimitInWatts
+		}
+	}
+
+	for i, ps := range x.PowerSupplies {
+		name := ps.Name
+		if name == "" {
+			name = ps.MemberID
+		}
+		psu := PowerSupplyMetrics{
+			Name:              fmt.Sprintf("%s_%d", name, i),
+			Health:            ps.Status.Health,
+			State:             ps.Status.State,
+			InputWatts:        ps.PowerInputWatts,
+			OutputWatts:       ps.PowerOutputWatts,
+			CapacityWatts:     ps.PowerCapacityWatts,
+			LineInputVoltage:  ps.LineInputVoltage,
+			EfficiencyPercent: ps.EfficiencyPercent,
+		}
+		if psu.OutputWatts == 0 {
+			psu.OutputWatts = ps.LastPowerOutputWatts
+		}
+		if psu.EfficiencyPercent == 0 && psu.InputWatts > 0 && psu.OutputWatts > 0 {
+			psu.EfficiencyPercent = psu.OutputWatts / psu.InputWatts * 100
+		}
+		_result.PowerSupplies = append(_result.PowerSupplies, psu)
+	}
+
+	return _result, nil
+}
+
+//setPowerLimit ... will PATCH PowerLimit.LimitInWatts of a chassis Power resource, nil removes the cap
+func setPowerLimit(c *IloClient, powerURL string, limit interface{}) error {
+	resp, _, _, err := queryData(c, "GET", powerURL, nil)
+	if err != nil {
+		return err
+	}
+
+	var x PowerResource
+
+	json.Unmarshal(resp, &x)
+
+	powerLimit := map[string]interface{}{"LimitInWatts": limit}
+
+	var payload map[string]interface{}
+	if len(x.PowerControl) > 0 {
+		payload = map[string]interface{}{
+			"PowerControl": []interface{}{map[string]interface{}{"PowerLimit": powerLimit}},
+		}
+	} else {
+		payload = map[string]interface{}{"PowerLimit": powerLimit}
+	}
+
+	data, _ := json.Marshal(payload)
+
+	resp, _, status, err := queryData(c, "PATCH", powerURL, data)
+	if err != nil {
+		return err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return redfishError(resp, status)
+	}
+
+	return nil
+}
+
+//getThermalMetrics ... will read the temperature and fan sensors of a chassis Thermal resource
+func getThermalMetrics(c *IloClient, thermalURL string) (ThermalMetrics, error) {
+	resp, _, _, err := queryData(c, "GET", thermalURL, nil)
+	if err != nil {
+		return ThermalMetrics{}, err
+	}
+
+	var (
+		x       ThermalResource
+		_result ThermalMetrics
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for _, t := range x.Temperatures {
+		reading := SensorReading{
+			Name:            t.Name,
+			Reading:         t.ReadingCelsius,
+			Units:           "Celsius",
+			PhysicalContext: t.PhysicalContext,
+			Health:          t.Status.Health,
+			State:           t.Status.State,
+			Thresholds: SensorThresholds{
+				UpperNonCritical: t.UpperThresholdNonCritical,
+				UpperCritical:    t.UpperThresholdCritical,
+				UpperFatal:       t.UpperThresholdFatal,
+				LowerNonCritical: t.LowerThresholdNonCritical,
+				LowerCritical:    t.LowerThresholdCritical,
+				LowerFatal:       t.LowerThresholdFatal,
+			},
+		}
+		if reading.Reading == nil {
+			reading.Reading = t.CurrentReading
+		}
+		if reading.PhysicalContext == "" {
+			reading.PhysicalContext = t.Context
+		}
+		_result.Temperatures = append(_result.Temperatures, reading)
+	}
+
+	for _, f := range x.Fans {
+		reading := SensorReading{
+			Name:            f.Name,
+			Reading:         f.Reading,
+			Units:           f.ReadingUnits,
+			PhysicalContext: f.PhysicalContext,
+			Health:          f.Status.Health,
+			State:           f.Status.State,
+			Thresholds: SensorThresholds{
+				UpperNonCritical: f.UpperThresholdNonCritical,
+				UpperCritical:    f.UpperThresholdCritical,
+				UpperFatal:       f.UpperThresholdFatal,
+				LowerNonCritical: f.LowerThresholdNonCritical,
+				LowerCritical:    f.LowerThresholdCritical,
+				LowerFatal:       f.LowerThresholdFatal,
+			},
+		}
+		if reading.Name == "" {
+			reading.Name = f.FanName
+		}
+		if reading.Reading == nil {
+			reading.Reading = f.CurrentReading
+		}
+		if reading.Units == "" {
+			reading.Units = f.Units
+		}
+		if reading.PhysicalContext == "" {
+			reading.PhysicalContext = f.Context
+		}
+		_result.Fans = append(_result.Fans, reading)
+	}
+
+	return _result, nil
+}
+
+//getMemoryModules ... will walk a system Memory collection and return the installed DIMMs
+func getMemoryModules(c *IloClient, memoryURL string) ([]MemoryModule, error) {
+	resp, _, _, err := queryData(c, "GET", memoryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x        MemberCountDell
+		_modules []MemoryModule
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+x.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var m MemoryResource
+
+		json.Unmarshal(resp, &m)
+
+		if m.Status.State == "Absent" || m.DIMMStatus == "NotPresent" || m.Oem.Hpe.DIMMStatus == "NotPresent" {
+			continue
+		}
+
+		_result := MemoryModule{
+			Slot:         m.DeviceLocator,
+			CapacityMiB:  m.CapacityMiB,
+			SpeedMHz:     m.OperatingSpeedMhz,
+			Type:         m.MemoryDeviceType,
+			Manufacturer: strings.TrimSpace(m.Manufacturer),
+			PartNumber:   strings.TrimSpace(m.PartNumber),
+			SerialNumber: strings.TrimSpace(m.SerialNumber),
+			RankCount:    m.RankCount,
+			Health:       m.Status.Health,
+			State:        m.Status.State,
+			Status:       m.Oem.Hpe.DIMMStatus,
+		}
+		if _result.Slot == "" {
+			_result.Slot = m.SocketLocator
+		}
+		if _result.Slot == "" {
+			_result.Slot = m.Name
+		}
+		if _result.CapacityMiB == 0 {
+			_result.CapacityMiB = m.SizeMB
+		}
+		if _result.SpeedMHz == 0 {
+			_result.SpeedMHz = m.MaximumFrequencyMHz
+		}
+		if _result.Type == "" {
+			_result.Type = m.DIMMType
+		}
+		if _result.RankCount == 0 {
+			_result.RankCount = m.Rank
+		}
+		if _result.Status == "" {
+			_result.Status = m.DIMMStatus
+		}
+
+		if m.Metrics.OdataId != "" {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+m.Metrics.OdataId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var mm MemoryMetricsResource
+
+			json.Unmarshal(resp, &mm)
+
+			_result.CorrectableErrors = mm.HealthData.AlarmTrips.CorrectableECCError
+			_result.UncorrectableErrors = mm.HealthData.AlarmTrips.UncorrectableECCError
+		}
+
+		_modules = append(_modules, _result)
+	}
+
+	return _modules, nil
+}
+
+//getProcessors ... will walk a system Processors collection and return the installed processors
+func getProcessors(c *IloClient, processorsURL string) ([]ProcessorData, error) {
+	resp, _, _, err := queryData(c, "GET", processorsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x           MemberCountDell
+		_processors []ProcessorData
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+x.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var p ProcessorResource
+
+		json.Unmarshal(resp, &p)
+
+		if p.Status.State == "Absent" {
+			continue
+		}
+
+		oem := p.Oem.Hpe
+		if oem.RatedSpeedMHz == 0 && oem.CoresEnabled == 0 {
+			oem = p.Oem.Hp
+		}
+
+		_result := ProcessorData{
+			Socket:       p.Socket,
+			Model:        strings.TrimSpace(p.Model),
+			Manufacturer: p.Manufacturer,
+			TotalCores:   p.TotalCores,
+			EnabledCores: oem.CoresEnabled,
+			TotalThreads: p.TotalThreads,
+			MaxSpeedMHz:  p.MaxSpeedMHz,
+			SpeedMHz:     p.Oem.Dell.DellProcessor.CurrentClockSpeedMhz,
+			Microcode:    p.ProcessorID.MicrocodeInfo,
+			Health:       p.Status.Health,
+			State:        p.Status.State,
+		}
+		if _result.Socket == "" {
+			_result.Socket = p.ID
+		}
+		if _result.EnabledCores == 0 {
+			_result.EnabledCores = p.Oem.Dell.DellProcessor.CoreCount
+		}
+		if _result.EnabledCores == 0 {
+			_result.EnabledCores = p.TotalCores
+		}
+		if _result.SpeedMHz == 0 {
+			_result.SpeedMHz = oem.RatedSpeedMHz
+		}
+		if _result.Microcode == "" && len(oem.MicrocodePatches) > 0 {
+			_result.Microcode = oem.MicrocodePatches[len(oem.MicrocodePatches)-1].PatchID
+		}
+		if _result.State == "" {
+			_result.State = oem.ConfigStatus.State
+		}
+
+		_processors = append(_processors, _result)
+	}
+
+	return _processors, nil
+}
+
+//storageDrive ... drive found by getStorageDrives with the ID of its controller
+type storageDrive struct {
+	controller string
+	drive      DriveResource
+}
+
+//getStorageDrives ... will walk a system Storage collection and return the drives of every controller
+func getStorageDrives(c *IloClient, storageURL string) ([]storageDrive, error) {
+	resp, _, _, err := queryData(c, "GET", storageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x       MemberCountDell
+		_drives []storageDrive
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+x.Members[i].OdataId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y struct {
+			ID     string    `json:"Id"`
+			Drives []Members `json:"Drives"`
+		}
+
+		json.Unmarshal(resp, &y)
+
+		for k := range y.Drives {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+y.Drives[k].OdataId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var z DriveResource
+
+			json.Unmarshal(resp, &z)
+
+			_drives = append(_drives, storageDrive{controller: y.ID, drive: z})
+		}
+	}
+
+	return _drives, nil
+}
+
+//DrivesBelowWearThreshold ... will return the drives with less than percent of their rated life left
+// Drives not reporting their wear are skipped.
+func DrivesBelowWearThreshold(drives []DriveHealth, percent float64) []DriveHealth {
+	var worn []DriveHealth
+
+	for _, d := range drives {
+		if d.LifeLeftPercent != nil && *d.LifeLeftPercent < percent {
+			worn = append(worn, d)
+		}
+	}
+
+	return worn
+}
+
+//driveHealth ... will build the DriveHealth of a drive from the standard Storage collection
+func driveHealth(f storageDrive) DriveHealth {
+	_result := DriveHealth{
+		ID:               f.drive.ID,
+		Controller:       f.controller,
+		Model:            strings.TrimSpace(f.drive.Model),
+		SerialNumber:     strings.TrimSpace(f.drive.SerialNumber),
+		MediaType:        f.drive.MediaType,
+		Protocol:         f.drive.Protocol,
+		CapacityBytes:    f.drive.CapacityBytes,
+		Health:           f.drive.Status.Health,
+		State:            f.drive.Status.State,
+		FailurePredicted: f.drive.FailurePredicted,
+		Hotspare:         f.drive.HotspareType,
+		LifeLeftPercent:  f.drive.PredictedMediaLifeLeftPercent,
+	}
+	if _result.Hotspare == "" {
+		_result.Hotspare = "None"
+	}
+	if _result.LifeLeftPercent == nil {
+		_result.LifeLeftPercent = f.drive.Oem.Dell.DellPhysicalDisk.RemainingRatedWriteEndurancePercent
+	}
+	if _result.LifeLeftPercent == nil {
+		_result.LifeLeftPercent = f.drive.Oem.Dell.DellPCIeSSD.RemainingRatedWriteEndurancePercent
+	}
+	return _result
+}
@@ -1,9 +1,17 @@
 package redfishapi
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 //StartServerHP ...
@@ -37,6 +45,29 @@ func (c *IloClient) StopServerHP() (string, error) {
 	return "Server Stopped", nil
 }
 
+//ResetServerHP ... will send a ComputerSystem.Reset of the given type (ResetOn, ResetForceRestart ...)
+// The type is checked against the values the system allows.
+func (c *IloClient) ResetServerHP(resetType string) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/"
+
+	if err := resetResource(c, url, "ComputerSystem.Reset", resetType); err != nil {
+		return "", err
+	}
+
+	return "Reset Requested", nil
+}
+
+//ResetIloHP ... will restart the iLO, the server keeps running. The iLO takes some time to come up
+func (c *IloClient) ResetIloHP() (string, error) {
+	url := c.Hostname + "/redfish/v1/Managers/1/"
+
+	if err := resetResource(c, url, "Manager.Reset", "GracefulRestart"); err != nil {
+		return "", err
+	}
+
+	return "Ilo Reset", nil
+}
+
 //GetSystemInfoHP ... Will fetch the system info
 func (c *IloClient) GetSystemInfoHP() (SystemData, error) {
 
@@ -76,10 +107,51 @@ func (c *IloClient) GetServerPowerStateHP() (string, error) {
 
 	json.Unmarshal(resp, &data)
 
+	// iLO 5 only reports PowerState
+	if data.Power == "" {
+		return data.PowerState, nil
+	}
+
 	return data.Power, nil
 
 }
 
+//WaitForPowerStateHP ... will wait until the server reaches the power state ("On", "Off") or ctx is done
+func (c *IloClient) WaitForPowerStateHP(ctx context.Context, state string) error {
+	return waitForPowerState(ctx, c.GetServerPowerStateHP, state)
+}
+
+//ShutdownHP ... will gracefully shut the server down, forcing it off when gracePeriod is over
+// iLO 4 has no GracefulShutdown, a momentary PushPowerButton is used instead.
+// Returns the path taken: ShutdownAlreadyOff, ShutdownGraceful or ShutdownForced.
+func (c *IloClient) ShutdownHP(ctx context.Context, gracePeriod time.Duration) (string, error) {
+	graceful := func() error {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+"/redfish/v1/Systems/1/", nil)
+		if err != nil {
+			return err
+		}
+
+		var x SystemInfoHP
+
+		json.Unmarshal(resp, &x)
+
+		resetType := ResetPushPowerButton
+		if checkAllowable("ResetType", ResetGracefulShutdown, x.Actions.ComputerSystemReset.ResetTypeRedfishAllowableValues) == nil &&
+			len(x.Actions.ComputerSystemReset.ResetTypeRedfishAllowableValues) > 0 {
+			resetType = ResetGracefulShutdown
+		}
+
+		_, err = c.ResetServerHP(resetType)
+		return err
+	}
+	force := func() error {
+		_, err := c.ResetServerHP(ResetForceOff)
+		return err
+	}
+
+	return shutdownServer(ctx, c.GetServerPowerStateHP, graceful, force, gracePeriod)
+}
+
 //CheckLoginHP ... Will check the credentials of the Server
 func (c *IloClient) CheckLoginHP() (string, error) {
 	url := c.Hostname + "/redfish/v1/Systems/1"
@@ -92,6 +164,175 @@ func (c *IloClient) CheckLoginHP() (string, error) {
 	return string(data.Status.Health), nil
 }
 
+//SetBootOverrideHP ... will set the boot source override of the server
+// target is one of the allowable values (BootTargetPxe, BootTargetHdd ... BootTargetNone), iLO 4 has no
+// BootTargetUefiHttp. mode is BootModeUEFI or BootModeLegacy (iLO 5 only, empty keeps the current one),
+// once limits the override to the next boot.
+func (c *IloClient) SetBootOverrideHP(target string, mode string, once bool) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/"
+
+	return setBootOverride(c, url, target, mode, "", once)
+}
+
+//SetUefiTargetBootOverrideHP ... will boot the server from a UEFI device path
+func (c *IloClient) SetUefiTargetBootOverrideHP(uefiTarget string, once bool) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/"
+
+	return setBootOverride(c, url, BootTargetUefiTarget, "", uefiTarget, once)
+}
+
+//GetBootOptionsHP ... will fetch the boot devices in persistent boot order
+// ID is the StructuredBootString and Name the BootString of the bios/boot resource.
+func (c *IloClient) GetBootOptionsHP() ([]BootOrderData, error) {
+	x, _, err := c.bootSettingsHP()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for i := range x.BootSources {
+		names[x.BootSources[i].StructuredBootString] = x.BootSources[i].BootString
+	}
+
+	var _bootOrder []BootOrderData
+
+	for i, id := range x.PersistentBootConfigOrder {
+		_result := BootOrderData{
+			Enabled: true,
+			Index:   i,
+			Name:    names[id],
+			ID:      id,
+		}
+
+		_bootOrder = append(_bootOrder, _result)
+	}
+
+	return _bootOrder, nil
+}
+
+//ReorderBootOptionsHP ... will move the given boot devices (ID or name) to the front of PersistentBootConfigOrder
+// The other devices keep their relative order, the change takes effect on the next reboot.
+func (c *IloClient) ReorderBootOptionsHP(devices []string) (string, error) {
+	options, err := c.GetBootOptionsHP()
+	if err != nil {
+		return "", err
+	}
+
+	order, err := reorderBootOptions(options, devices)
+	if err != nil {
+		return "", err
+	}
+
+	_, url, err := c.bootSettingsHP()
+	if err != nil {
+		return "", err
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"PersistentBootConfigOrder": order})
+
+	resp, _, status, err := queryData(c, "PATCH", url, data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return "", redfishError(resp, status)
+	}
+
+	return "Boot Order Pending Reboot", nil
+}
+
+//bootSettingsHP ... will fetch the bios boot resource and the URL its pending settings are written to
+// iLO 5 links it from the Bios resource (bios/oem/hpe/boot), iLO 4 serves it at bios/boot.
+func (c *IloClient) bootSettingsHP() (BootSettingsHP, string, error) {
+	var x BootSettingsHP
+
+	resp, _, _, err := queryData(c, "GET", c.Hostname+"/redfish/v1/Systems/1/Bios/", nil)
+	if err != nil {
+		return x, "", err
+	}
+
+	var bios BiosResourceHP
+
+	json.Unmarshal(resp, &bios)
+
+	url := c.Hostname + "/redfish/v1/Systems/1/bios/boot/"
+	if bios.Oem.Hpe.Links.Boot.OdataID != "" {
+		url = c.Hostname + bios.Oem.Hpe.Links.Boot.OdataID
+	}
+
+	resp, _, _, err = queryData(c, "GET", url, nil)
+	if err != nil {
+		return x, "", err
+	}
+
+	json.Unmarshal(resp, &x)
+
+	if x.RedfishSettings.SettingsObject.OdataID != "" {
+		return x, c.Hostname + x.RedfishSettings.SettingsObject.OdataID, nil
+	}
+
+	return x, strings.TrimSuffix(url, "/") + "/settings/", nil
+}
+
+//SetBootOptionEnabledHP ... will enable or disable a boot device (BootOptionReference or name)
+// Uses the BootOptions collection, only available on iLO 5.
+func (c *IloClient) SetBootOptionEnabledHP(device string, enabled bool) (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/"
+
+	return setBootOptionEnabled(c, url, device, enabled)
+}
+
+//SetHTTPBootHP ... will configure UEFI HTTP boot through the UrlBootFile and PreBoot network BIOS attributes
+// Only available on iLO 5, the settings take effect on the next reboot.
+func (c *IloClient) SetHTTPBootHP(cfg HTTPBootConfig) (BiosSettingsResultHP, error) {
+	attrs := map[string]interface{}{
+		"UrlBootFile": cfg.URI,
+	}
+	if cfg.Interface != "" {
+		attrs["PreBootNetwork"] = cfg.Interface
+	}
+	if cfg.IPMode != "" {
+		attrs["PrebootNetworkEnvPolicy"] = cfg.IPMode
+	}
+
+	if cfg.IPMode == "IPv6" {
+		attrs["Ipv6ConfigPolicy"] = "Automatic"
+		if cfg.Address != "" {
+			attrs["Ipv6ConfigPolicy"] = "Manual"
+			attrs["Ipv6Address"] = cfg.Address
+			attrs["Ipv6Gateway"] = cfg.Gateway
+		}
+		if cfg.DNS != "" {
+			attrs["Ipv6PrimaryDNS"] = cfg.DNS
+		}
+	} else {
+		attrs["Dhcpv4"] = "Enabled"
+		if cfg.Address != "" {
+			attrs["Dhcpv4"] = "Disabled"
+			attrs["Ipv4Address"] = cfg.Address
+			attrs["Ipv4SubnetMask"] = cfg.Netmask
+			attrs["Ipv4Gateway"] = cfg.Gateway
+		}
+		if cfg.DNS != "" {
+			attrs["Ipv4PrimaryDNS"] = cfg.DNS
+		}
+	}
+
+	return c.SetBiosSettingsHP(attrs)
+}
+
+//SetNetworkISCSIBootHP ... will configure iSCSI boot on a NetworkDeviceFunction of a network adapter
+// The settings take effect on the next reboot.
+func (c *IloClient) SetNetworkISCSIBootHP(adapter string, function string, cfg ISCSIBootConfig) (string, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/1/NetworkAdapters/" + adapter + "/NetworkDeviceFunctions/" + function + "/"
+
+	if _, err := setISCSIBoot(c, url, cfg, ""); err != nil {
+		return "", err
+	}
+
+	return "iSCSI Boot Pending Reboot", nil
+}
+
 //GetFirmwareHP ... will fetch the Firmware details
 func (c *IloClient) GetFirmwareHP() ([]FirmwareData, error) {
 
@@ -179,93 +420,202 @@ func (c *IloClient) GetFirmwareHP() ([]FirmwareData, error) {
 	return _firmdata, nil
 }
 
-//GetThermalHealthHP ... will fetch the Thermal Health
-func (c *IloClient) GetThermalHealthHP() ([]HealthList, error) {
-	url := c.Hostname + "/redfish/v1/Chassis/1/Thermal/"
+//UploadFirmwareHP ... will push a firmware image to iLO over HTTP and return the task location
+// iLO with a MultipartHttpPushUri gets the standard multipart update, otherwise the image goes to
+// HttpPushUri with a session and can be kept in the component repository (UpdateRepository) and/or
+// flashed right away (UpdateTarget). HttpPushUri creates no task, "" is returned and the flash is
+// followed with WaitForFlashHP.
+func (c *IloClient) UploadFirmwareHP(fileName string, r io.Reader, size int64, opts FirmwareUploadOptionsHP, progress UploadProgress) (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/"
+
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	var (
-		x       ThermalHealthListHP
-		_health []HealthList
-	)
+	var x UpdateServiceHP
 
 	json.Unmarshal(resp, &x)
 
-	for i := range x.Fans {
-		_result := HealthList{Name: x.Fans[i].FanName,
-			Health: x.Fans[i].Status.Health,
-			State:  x.Fans[i].Status.State}
-		_health = append(_health, _result)
+	if x.MultipartHTTPPushURI != "" {
+		params := FirmwareUpdateParameters{
+			Oem: map[string]interface{}{
+				"Hpe": map[string]interface{}{
+					"UpdateRepository": opts.UpdateRepository,
+					"UpdateTarget":     opts.UpdateTarget,
+				},
+			},
+		}
+		return multipartPushUpdate(c, x.MultipartHTTPPushURI, fileName, r, size, params, progress)
 	}
 
-	for i := range x.Temperatures {
-		_result := HealthList{Name: x.Temperatures[i].Name,
-			Health: x.Temperatures[i].Status.Health,
-			State:  x.Temperatures[i].Status.State}
-		_health = append(_health, _result)
+	if x.HTTPPushURI == "" {
+		return "", errors.New("HTTP push update is not supported by this iLO")
 	}
 
-	return _health, nil
+	token, session, err := createSession(c)
+	if err != nil {
+		return "", err
+	}
+	defer deleteSession(c, session)
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"UpdateRepository": opts.UpdateRepository,
+		"UpdateTarget":     opts.UpdateTarget,
+		"ETag":             fileName,
+		"Section":          0,
+	})
+	parts := []formPart{
+		{name: "sessionKey", data: []byte(token)},
+		{name: "parameters", contentType: "application/json", data: params},
+	}
+	headers := map[string]string{"Cookie": "sessionKey=" + token}
+
+	resp, _, status, err := uploadData(c, c.Hostname+x.HTTPPushURI, parts, "file", fileName, r, size, headers, progress)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 {
+		return "", redfishError(resp, status)
+	}
+
+	return "", nil
 }
 
-//GetPowerHealthHP ... will fetch the Power Health
-func (c *IloClient) GetPowerHealthHP() ([]HealthList, error) {
-	url := c.Hostname + "/redfish/v1/Chassis/1/Power/"
-	resp, _, _, err := queryData(c, "GET", url, nil)
+//UploadFirmwareFileHP ... will push a local firmware file to iLO over HTTP
+func (c *IloClient) UploadFirmwareFileHP(path string, opts FirmwareUploadOptionsHP, progress UploadProgress) (string, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	defer f.Close()
 
-	var (
-		x       PowerDataHP
-		_health []HealthList
-	)
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
 
-	json.Unmarshal(resp, &x)
+	return c.UploadFirmwareHP(filepath.Base(path), f, info.Size(), opts, progress)
+}
 
-	for i := range x.PowerSupplies {
-		_name := fmt.Sprintf("%s_%d", x.PowerSupplies[i].Name, i)
-		_result := HealthList{Name: _name,
-			Health: x.PowerSupplies[i].Status.Health,
-			State:  x.PowerSupplies[i].Status.State}
-		_health = append(_health, _result)
+//FirmwareUploadHP ... will make iLO fetch and apply an image from a remote repo
+// Supports iLO 5, the image can be kept in the component repository and/or flashed right away.
+func (c *IloClient) FirmwareUploadHP(repoUrl string, opts FirmwareUploadOptionsHP) (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate/"
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"ImageURI":         repoUrl,
+		"UpdateRepository": opts.UpdateRepository,
+		"UpdateTarget":     opts.UpdateTarget,
+	})
+
+	resp, _, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 {
+		return "", redfishError(resp, status)
 	}
 
-	return _health, nil
+	return "Update Started", nil
 }
 
-//GetInterfaceHealthHP ... will fetch the Interface Health
-func (c *IloClient) GetInterfaceHealthHP() ([]HealthList, error) {
-	url := c.Hostname + "/redfish/v1/Managers/1/EthernetInterfaces/"
+//GetFlashStatusHP ... will fetch the flash progress of the iLO Update Service
+func (c *IloClient) GetFlashStatusHP() (FlashStatusHP, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/"
+
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return FlashStatusHP{}, err
+	}
+
+	var x UpdateServiceHP
+
+	json.Unmarshal(resp, &x)
+
+	_result := FlashStatusHP{
+		State:                x.Oem.Hpe.State,
+		FlashProgressPercent: x.Oem.Hpe.FlashProgressPercent,
+		Result:               x.Oem.Hpe.Result.MessageID,
+		UpdateTarget:         x.Oem.Hpe.UpdateTarget,
 	}
 
+	return _result, nil
+}
+
+//WaitForFlashHP ... will poll the flash progress until it is Complete or in Error
+// iLO 5 goes back to Idle after some flashes, Idle after an Uploading, Verifying, Writing or Updating
+// state counts as done and the result is checked. Idle on the first poll with nothing pending in the
+// update task queue is an error. iLO going away while it resets after flashing its own firmware is
+// retried until the timeout.
+func (c *IloClient) WaitForFlashHP(interval time.Duration, timeout time.Duration) (FlashStatusHP, error) {
+	deadline := time.Now().Add(timeout)
+
 	var (
-		x       EthernetInterfacesHP
-		_health []HealthList
+		status   FlashStatusHP
+		progress bool
+		first    = true
 	)
 
-	json.Unmarshal(resp, &x)
+	for {
+		x, err := c.GetFlashStatusHP()
+		if err != nil && err.Error() != StatusInternalServerError {
+			return status, err
+		}
+		if err == nil {
+			status = x
+			switch status.State {
+			case "Complete", "Error":
+				return status, nil
+			case "Uploading", "Verifying", "Writing", "Updating":
+				progress = true
+			case "Idle":
+				if progress {
+					if strings.Contains(status.Result, "Fail") || strings.Contains(status.Result, "Error") {
+						return status, fmt.Errorf("flash finished with %s", status.Result)
+					}
+					return status, nil
+				}
+				if first {
+					queued, err := c.flashQueuedHP()
+					if err != nil {
+						return status, err
+					}
+					if !queued {
+						return status, errors.New("no flash in progress and nothing queued")
+					}
+				}
+			}
+			first = false
+		}
 
-	for i := range x.Items {
-		_result := HealthList{Name: x.Items[i].Name,
-			Health: x.Items[i].Status.Health,
-			State:  x.Items[i].Status.State}
-		_health = append(_health, _result)
+		if time.Now().After(deadline) {
+			return status, fmt.Errorf("timed out waiting for flash, last state %q", status.State)
+		}
+		time.Sleep(interval)
 	}
+}
 
-	return _health, nil
+//flashQueuedHP ... will report if the update task queue holds a task still to run
+func (c *IloClient) flashQueuedHP() (bool, error) {
+	tasks, err := c.GetUpdateTaskQueueHP()
+	if err != nil {
+		return false, err
+	}
+
+	for _, t := range tasks {
+		if t.State == "Pending" || t.State == "InProgress" {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
-//GetProcessorHealthHP ... will Fetch the Processor Health Details
-func (c *IloClient) GetProcessorInfoHP() ([]ProcessorInfoHP, error) {
+//GetComponentRepositoryHP ... will fetch the components stored in the iLO repository
+func (c *IloClient) GetComponentRepositoryHP() ([]ComponentRepositoryHP, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/ComponentRepository/"
 
-	url := c.Hostname + "/redfish/v1/Systems/1/Processors/"
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
 		return nil, err
@@ -273,7 +623,7 @@ func (c *IloClient) GetProcessorInfoHP() ([]ProcessorInfoHP, error) {
 
 	var (
 		x           MemberCountHP
-		processData []ProcessorInfoHP
+		_components []ComponentRepositoryHP
 	)
 
 	json.Unmarshal(resp, &x)
@@ -285,29 +635,83 @@ func (c *IloClient) GetProcessorInfoHP() ([]ProcessorInfoHP, error) {
 			return nil, err
 		}
 
-		var y ProcessorInfoHP
+		var y ComponentRepositoryHP
 
 		json.Unmarshal(resp, &y)
 
-		processData = append(processData, y)
+		_components = append(_components, y)
 	}
 
-	return processData, nil
+	return _components, nil
+}
+
+//DeleteComponentHP ... will remove a component from the iLO repository by file name
+func (c *IloClient) DeleteComponentHP(fileName string) (string, error) {
+	components, err := c.GetComponentRepositoryHP()
+	if err != nil {
+		return "", err
+	}
+
+	for _, comp := range components {
+		if comp.Filename == fileName {
+			_, _, _, err := queryData(c, "DELETE", c.Hostname+comp.OdataID, nil)
+			if err != nil {
+				return "", err
+			}
+			return "Component Deleted", nil
+		}
+	}
 
+	return "", fmt.Errorf("component %q is not in the repository", fileName)
 }
 
-//GetProcessorHealthHP ... will Fetch the Processor Health Details
-func (c *IloClient) GetProcessorHealthHP() ([]HealthList, error) {
+//CreateInstallSetHP ... will create an install set from components in the repository
+// Items without UpdatableBy are flashed by the iLO (Bmc).
+func (c *IloClient) CreateInstallSetHP(name string, description string, items []InstallSetItemHP) (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/InstallSets/"
+
+	var sequence []map[string]interface{}
+	for _, item := range items {
+		updatableBy := item.UpdatableBy
+		if len(updatableBy) == 0 {
+			updatableBy = []string{"Bmc"}
+		}
+		sequence = append(sequence, map[string]interface{}{
+			"Name":        "Install " + item.Filename,
+			"Filename":    item.Filename,
+			"UpdatableBy": updatableBy,
+		})
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"Name":        name,
+		"Description": description,
+		"Sequence":    sequence,
+	})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//GetInstallSetsHP ... will fetch the install sets
+func (c *IloClient) GetInstallSetsHP() ([]InstallSetHP, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/InstallSets/"
 
-	url := c.Hostname + "/redfish/v1/Systems/1/Processors/"
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var (
-		x             MemberCountHP
-		processHealth []HealthList
+		x            MemberCountHP
+		_installSets []InstallSetHP
 	)
 
 	json.Unmarshal(resp, &x)
@@ -319,26 +723,505 @@ func (c *IloClient) GetProcessorHealthHP() ([]HealthList, error) {
 			return nil, err
 		}
 
-		var y ProcessorInfoHP
+		var y InstallSetHP
 
 		json.Unmarshal(resp, &y)
 
-		procHealth := HealthList{
-			Name:   y.ID,
-			Health: y.Status.Health,
-			State:  y.Oem.Hp.ConfigStatus.State,
-		}
-		processHealth = append(processHealth, procHealth)
+		_installSets = append(_installSets, y)
 	}
 
-	return processHealth, nil
+	return _installSets, nil
+}
+
+//InvokeInstallSetHP ... will queue the install set components as update tasks
+func (c *IloClient) InvokeInstallSetHP(installSet string, clearTaskQueue bool) (string, error) {
+	url := strings.TrimSuffix(installSet, "/") + "/Actions/HpeComponentInstallSet.Invoke/"
+	if !strings.HasPrefix(url, "http") {
+		url = c.Hostname + url
+	}
 
+	data, _ := json.Marshal(map[string]interface{}{
+		"ClearTaskQueue": clearTaskQueue,
+	})
+
+	resp, _, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 && status != 202 {
+		return "", redfishError(resp, status)
+	}
+
+	return "Install Set Invoked", nil
 }
 
-//GetUserAccountsHP ... will fetch the current User Accounts
-func (c *IloClient) GetUserAccountsHP() ([]Accounts, error) {
+//DeleteInstallSetHP ... will delete an install set
+func (c *IloClient) DeleteInstallSetHP(installSet string) (string, error) {
+	url := installSet
+	if !strings.HasPrefix(url, "http") {
+		url = c.Hostname + url
+	}
 
-	url := c.Hostname + "/redfish/v1/AccountService/Accounts"
+	_, _, _, err := queryData(c, "DELETE", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return "Install Set Deleted", nil
+}
+
+//GetUpdateTaskQueueHP ... will fetch the update task queue
+func (c *IloClient) GetUpdateTaskQueueHP() ([]UpdateTaskHP, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/UpdateTaskQueue/"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x      MemberCountHP
+		_tasks []UpdateTaskHP
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		_url := c.Hostname + x.Members[i].OdataID
+		resp, _, _, err := queryData(c, "GET", _url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y UpdateTaskHP
+
+		json.Unmarshal(resp, &y)
+
+		_tasks = append(_tasks, y)
+	}
+
+	return _tasks, nil
+}
+
+//AddUpdateTaskHP ... will queue a repository component to be flashed
+func (c *IloClient) AddUpdateTaskHP(item InstallSetItemHP) (string, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/UpdateTaskQueue/"
+
+	updatableBy := item.UpdatableBy
+	if len(updatableBy) == 0 {
+		updatableBy = []string{"Bmc"}
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"Name":        "Update " + item.Filename,
+		"Filename":    item.Filename,
+		"Command":     "ApplyUpdate",
+		"UpdatableBy": updatableBy,
+	})
+
+	resp, header, status, err := queryData(c, "POST", url, data)
+	if err != nil {
+		return "", err
+	}
+	if header.Get("Location") == "" {
+		return "", redfishError(resp, status)
+	}
+
+	return header.Get("Location"), nil
+}
+
+//DeleteUpdateTaskHP ... will remove a task from the update task queue
+func (c *IloClient) DeleteUpdateTaskHP(task string) (string, error) {
+	url := task
+	if !strings.HasPrefix(url, "http") {
+		url = c.Hostname + url
+	}
+
+	_, _, _, err := queryData(c, "DELETE", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return "Task Deleted", nil
+}
+
+//ClearUpdateTaskQueueHP ... will delete all the tasks in the update task queue
+func (c *IloClient) ClearUpdateTaskQueueHP() (string, error) {
+	tasks, err := c.GetUpdateTaskQueueHP()
+	if err != nil {
+		return "", err
+	}
+
+	for _, task := range tasks {
+		_, _, _, err := queryData(c, "DELETE", c.Hostname+task.OdataID, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "Tasks Deleted", nil
+}
+
+//GetRollbackFirmwareHP ... will fetch the components that keep a backup image (the redundant System ROM)
+// iLO 4 reports them in /Systems/1/FirmwareInventory, iLO 5 in /UpdateService/FirmwareInventory.
+func (c *IloClient) GetRollbackFirmwareHP() ([]RollbackFirmware, error) {
+
+	url := c.Hostname + "/redfish/v1/Systems/1/FirmwareInventory/"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		x         FirmwareInventoryHP
+		active    string
+		_rollback []RollbackFirmware
+	)
+	json.Unmarshal(resp, &x)
+
+	if len(x.Current.SystemRomActive) > 0 {
+		active = x.Current.SystemRomActive[0].VersionString
+	}
+
+	for i := range x.Current.SystemRomBackup {
+		_result := RollbackFirmware{
+			ID:               x.Current.SystemRomBackup[i].Key,
+			Name:             x.Current.SystemRomBackup[i].Name,
+			InstalledVersion: active,
+			RollbackVersion:  x.Current.SystemRomBackup[i].VersionString,
+		}
+		_rollback = append(_rollback, _result)
+	}
+
+	if len(x.Current.SystemRomActive) == 0 && len(x.Current.SystemRomBackup) == 0 {
+		return c.getRollbackFirmwareILO5HP()
+	}
+
+	return _rollback, nil
+}
+
+//getRollbackFirmwareILO5HP ... will find the Redundant System ROM in the iLO 5 firmware inventory
+func (c *IloClient) getRollbackFirmwareILO5HP() ([]RollbackFirmware, error) {
+	url := c.Hostname + "/redfish/v1/UpdateService/FirmwareInventory/"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x         MemberCountHP
+		active    string
+		backups   []SoftwareInventoryHP
+		_rollback []RollbackFirmware
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+x.Members[i].OdataID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y SoftwareInventoryHP
+
+		json.Unmarshal(resp, &y)
+
+		switch y.Name {
+		case "System ROM":
+			active = y.Version
+		case "Redundant System ROM":
+			backups = append(backups, y)
+		}
+	}
+
+	for _, b := range backups {
+		_result := RollbackFirmware{
+			ID:               b.ID,
+			Name:             b.Name,
+			InstalledVersion: active,
+			RollbackVersion:  b.Version,
+		}
+		_rollback = append(_rollback, _result)
+	}
+
+	return _rollback, nil
+}
+
+//RollbackSystemRomHP ... will switch to the backup System ROM through the RomSelection BIOS attribute
+// The switch happens on the next reboot, the result reports the pending state. A BIOS without
+// RomSelection returns an error.
+func (c *IloClient) RollbackSystemRomHP() (FirmwareUpdateResult, error) {
+	rollbacks, err := c.GetRollbackFirmwareHP()
+	if err != nil {
+		return FirmwareUpdateResult{}, err
+	}
+	if len(rollbacks) == 0 {
+		return FirmwareUpdateResult{}, errors.New("no backup System ROM available")
+	}
+
+	attrs, err := c.GetBiosAttributesHP()
+	if err != nil {
+		return FirmwareUpdateResult{}, err
+	}
+	if !attrs.Has("RomSelection") {
+		return FirmwareUpdateResult{}, errors.New("BIOS has no RomSelection attribute, ROM rollback is not supported over Redfish")
+	}
+
+	_, err = c.patchBiosSettingsHP(map[string]interface{}{
+		"RomSelection": "BackupRom",
+	})
+	if err != nil {
+		return FirmwareUpdateResult{}, err
+	}
+
+	_result := FirmwareUpdateResult{
+		ID:       rollbacks[0].ID,
+		Name:     rollbacks[0].Name,
+		Version:  rollbacks[0].RollbackVersion,
+		JobState: "PendingReboot",
+		Message:  "Backup System ROM will be active after the next reboot",
+		Success:  true,
+	}
+
+	return _result, nil
+}
+
+//biosSettingsURLHP ... will find the pending BIOS settings resource, /Bios/Settings on iLO 5 and bios/settings on iLO 4
+func (c *IloClient) biosSettingsURLHP() (string, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/Bios/"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var x BiosResourceHP
+
+	json.Unmarshal(resp, &x)
+
+	if x.RedfishSettings.SettingsObject.OdataID != "" {
+		return c.Hostname + x.RedfishSettings.SettingsObject.OdataID, nil
+	}
+
+	return c.Hostname + "/redfish/v1/Systems/1/bios/settings/", nil
+}
+
+//GetThermalMetricsHP ... will fetch the temperature (Celsius) and fan speed readings with their thresholds
+func (c *IloClient) GetThermalMetricsHP() (ThermalMetrics, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/1/Thermal/"
+
+	return getThermalMetrics(c, url)
+}
+
+//GetThermalHealthHP ... will fetch the Thermal Health
+func (c *IloClient) GetThermalHealthHP() ([]HealthList, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/1/Thermal/"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x       ThermalHealthListHP
+		_health []HealthList
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Fans {
+		_result := HealthList{Name: x.Fans[i].FanName,
+			Health: x.Fans[i].Status.Health,
+			State:  x.Fans[i].Status.State}
+		_health = append(_health, _result)
+	}
+
+	for i := range x.Temperatures {
+		_result := HealthList{Name: x.Temperatures[i].Name,
+			Health: x.Temperatures[i].Status.Health,
+			State:  x.Temperatures[i].Status.State}
+		_health = append(_health, _result)
+	}
+
+	return _health, nil
+}
+
+//GetPowerMetricsHP ... will fetch the power consumption, power cap and power supply readings
+func (c *IloClient) GetPowerMetricsHP() (PowerMetrics, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/1/Power/"
+
+	return getPowerMetrics(c, url)
+}
+
+//SetPowerCapHP ... will limit the server power consumption to watts
+func (c *IloClient) SetPowerCapHP(watts int) (string, error) {
+	if watts <= 0 {
+		return "", fmt.Errorf("invalid power cap %d", watts)
+	}
+
+	url := c.Hostname + "/redfish/v1/Chassis/1/Power/"
+
+	if err := setPowerLimit(c, url, watts); err != nil {
+		return "", err
+	}
+
+	return "Power Cap Set", nil
+}
+
+//RemovePowerCapHP ... will remove the power cap
+func (c *IloClient) RemovePowerCapHP() (string, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/1/Power/"
+
+	if err := setPowerLimit(c, url, nil); err != nil {
+		return "", err
+	}
+
+	return "Power Cap Removed", nil
+}
+
+//GetPowerHealthHP ... will fetch the Power Health
+func (c *IloClient) GetPowerHealthHP() ([]HealthList, error) {
+	url := c.Hostname + "/redfish/v1/Chassis/1/Power/"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x       PowerDataHP
+		_health []HealthList
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.PowerSupplies {
+		_name := fmt.Sprintf("%s_%d", x.PowerSupplies[i].Name, i)
+		_result := HealthList{Name: _name,
+			Health: x.PowerSupplies[i].Status.Health,
+			State:  x.PowerSupplies[i].Status.State}
+		_health = append(_health, _result)
+	}
+
+	return _health, nil
+}
+
+//GetInterfaceHealthHP ... will fetch the Interface Health
+func (c *IloClient) GetInterfaceHealthHP() ([]HealthList, error) {
+	url := c.Hostname + "/redfish/v1/Managers/1/EthernetInterfaces/"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x       EthernetInterfacesHP
+		_health []HealthList
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Items {
+		_result := HealthList{Name: x.Items[i].Name,
+			Health: x.Items[i].Status.Health,
+			State:  x.Items[i].Status.State}
+		_health = append(_health, _result)
+	}
+
+	return _health, nil
+}
+
+//GetProcessorsHP ... will fetch the installed processors with model, cores, speed and microcode
+// Same result as GetProcessorsDell, GetProcessorInfoHP returns the raw iLO data.
+func (c *IloClient) GetProcessorsHP() ([]ProcessorData, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/Processors/"
+
+	return getProcessors(c, url)
+}
+
+//GetMemoryHP ... will fetch the installed DIMMs with their health and ECC error status
+func (c *IloClient) GetMemoryHP() ([]MemoryModule, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/Memory/"
+
+	return getMemoryModules(c, url)
+}
+
+//GetProcessorHealthHP ... will Fetch the Processor Health Details
+func (c *IloClient) GetProcessorInfoHP() ([]ProcessorInfoHP, error) {
+
+	url := c.Hostname + "/redfish/v1/Systems/1/Processors/"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x           MemberCountHP
+		processData []ProcessorInfoHP
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		_url := c.Hostname + x.Members[i].OdataID
+		resp, _, _, err := queryData(c, "GET", _url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y ProcessorInfoHP
+
+		json.Unmarshal(resp, &y)
+
+		processData = append(processData, y)
+	}
+
+	return processData, nil
+
+}
+
+//GetProcessorHealthHP ... will Fetch the Processor Health Details
+func (c *IloClient) GetProcessorHealthHP() ([]HealthList, error) {
+
+	url := c.Hostname + "/redfish/v1/Systems/1/Processors/"
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		x             MemberCountHP
+		processHealth []HealthList
+	)
+
+	json.Unmarshal(resp, &x)
+
+	for i := range x.Members {
+		_url := c.Hostname + x.Members[i].OdataID
+		resp, _, _, err := queryData(c, "GET", _url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var y ProcessorInfoHP
+
+		json.Unmarshal(resp, &y)
+
+		procHealth := HealthList{
+			Name:   y.ID,
+			Health: y.Status.Health,
+			State:  y.Oem.Hp.ConfigStatus.State,
+		}
+		processHealth = append(processHealth, procHealth)
+	}
+
+	return processHealth, nil
+
+}
+
+//GetUserAccountsHP ... will fetch the current User Accounts
+func (c *IloClient) GetUserAccountsHP() ([]Accounts, error) {
+
+	url := c.Hostname + "/redfish/v1/AccountService/Accounts"
 
 	resp, _, _, err := queryData(c, "GET", url, nil)
 	if err != nil {
@@ -517,7 +1400,7 @@ func (c *IloClient) GetBiosDataHP() (BiosDataHP, error) {
 		PowerRegulator:               x.PowerRegulator,
 		PreBootNetwork:               x.PreBootNetwork,
 		ProcAes:                      x.ProcAes,
-		ProcCoreDisable:              x.ProcCoreDisable,
+		ProcCoreDisable:              int(x.ProcCoreDisable),
 		ProcHyperthreading:           x.ProcHyperthreading,
 		ProcNoExecute:                x.ProcNoExecute,
 		ProcTurbo:                    x.ProcTurbo,
@@ -574,12 +1457,377 @@ func (c *IloClient) GetBiosDataHP() (BiosDataHP, error) {
 		VirtualInstallDisk:           x.VirtualInstallDisk,
 		VirtualSerialPort:            x.VirtualSerialPort,
 		VlanControl:                  x.VlanControl,
-		VlanID:                       x.VlanID,
-		VlanPriority:                 x.VlanPriority,
+		VlanID:                       int(x.VlanID),
+		VlanPriority:                 int(x.VlanPriority),
 		WakeOnLan:                    x.WakeOnLan,
 	}
 
-	return _BiosData, nil
+	return _BiosData, nil
+}
+
+//GetBiosAttributesHP ... will fetch every current BIOS attribute as a map
+// Unlike GetBiosDataHP no attribute is dropped and values keep the type the firmware returns.
+func (c *IloClient) GetBiosAttributesHP() (BiosAttributes, error) {
+	_, attrs, err := c.getBiosHP("/redfish/v1/Systems/1/Bios/")
+	if err != nil {
+		return nil, err
+	}
+
+	return attrs, nil
+}
+
+//GetBiosRegistryHP ... will fetch the BIOS attribute registry referenced by the Bios resource
+func (c *IloClient) GetBiosRegistryHP() (BiosRegistry, error) {
+	x, _, err := c.getBiosHP("/redfish/v1/Systems/1/Bios/")
+	if err != nil {
+		return BiosRegistry{}, err
+	}
+
+	return getBiosRegistry(c, x.AttributeRegistry)
+}
+
+//SetBiosSettingsHP ... will validate and stage BIOS attributes
+// PATCHes /Systems/1/bios/settings/ on iLO 4 and /Bios/Settings on iLO 5, the values
+// take effect on the next reboot. The result lists the current and pending value of each attribute.
+/* Payload
+map[string]interface{}{"BootMode": "Uefi", "ProcHyperthreading": "Disabled"}
+*/
+func (c *IloClient) SetBiosSettingsHP(attrs map[string]interface{}) (BiosSettingsResultHP, error) {
+	x, current, err := c.getBiosHP("/redfish/v1/Systems/1/Bios/")
+	if err != nil {
+		return BiosSettingsResultHP{}, err
+	}
+
+	reg, err := getBiosRegistry(c, x.AttributeRegistry)
+	if err != nil {
+		return BiosSettingsResultHP{}, err
+	}
+	if err := validateBiosAttributes(reg, current, attrs); err != nil {
+		return BiosSettingsResultHP{}, err
+	}
+
+	resp, err := c.patchBiosSettingsHP(attrs)
+	if err != nil {
+		return BiosSettingsResultHP{}, err
+	}
+
+	var k RedfishErrorResponse
+
+	json.Unmarshal(resp, &k)
+
+	_result := BiosSettingsResultHP{RebootRequired: true}
+	if len(k.Error.MessageExtendedInfo) > 0 {
+		_result.Message = k.Error.MessageExtendedInfo[0].MessageID
+	}
+
+	settingsURL, err := c.biosSettingsURLHP()
+	if err != nil {
+		return _result, err
+	}
+
+	_, pending, err := c.getBiosHP(strings.TrimPrefix(settingsURL, c.Hostname))
+	if err != nil {
+		return _result, err
+	}
+
+	for name := range attrs {
+		_result.Pending = append(_result.Pending, BiosPendingValue{
+			Attribute: name,
+			Current:   current[name],
+			Pending:   pending[name],
+		})
+	}
+	sort.Slice(_result.Pending, func(i, j int) bool {
+		return _result.Pending[i].Attribute < _result.Pending[j].Attribute
+	})
+
+	return _result, nil
+}
+
+//GetBiosPendingHP ... will compare the staged BIOS settings with the active ones
+// iLO has no configuration jobs, the staged values are applied on the next reboot.
+func (c *IloClient) GetBiosPendingHP() (BiosPendingSettings, error) {
+	_, current, err := c.getBiosHP("/redfish/v1/Systems/1/Bios/")
+	if err != nil {
+		return BiosPendingSettings{}, err
+	}
+
+	settingsURL, err := c.biosSettingsURLHP()
+	if err != nil {
+		return BiosPendingSettings{}, err
+	}
+
+	_, staged, err := c.getBiosHP(strings.TrimPrefix(settingsURL, c.Hostname))
+	if err != nil {
+		return BiosPendingSettings{}, err
+	}
+
+	return BiosPendingSettings{Pending: diffBiosAttributes(current, staged)}, nil
+}
+
+//CancelBiosPendingHP ... will reset the staged BIOS settings back to the active values
+// Staged attributes the server has no active value for cannot be reverted, they are returned in the error.
+func (c *IloClient) CancelBiosPendingHP() (string, error) {
+	pending, err := c.GetBiosPendingHP()
+	if err != nil {
+		return "", err
+	}
+	if len(pending.Pending) == 0 {
+		return "No Pending Settings", nil
+	}
+
+	var skipped []string
+
+	attrs := make(map[string]interface{})
+	for _, p := range pending.Pending {
+		if p.Current != nil {
+			attrs[p.Attribute] = p.Current
+		} else {
+			skipped = append(skipped, p.Attribute)
+		}
+	}
+
+	if len(attrs) > 0 {
+		if _, err := c.patchBiosSettingsHP(attrs); err != nil {
+			return "", err
+		}
+	}
+
+	if len(skipped) > 0 {
+		return "", fmt.Errorf("pending attributes without a current value were left staged: %s", strings.Join(skipped, ", "))
+	}
+
+	return "Pending Settings Cleared", nil
+}
+
+//patchBiosSettingsHP ... will stage attributes in the Bios settings resource
+// iLO 5 nests the attributes under Attributes, iLO 4 keeps them at the top level.
+func (c *IloClient) patchBiosSettingsHP(attrs map[string]interface{}) ([]byte, error) {
+	x, _, err := c.getBiosHP("/redfish/v1/Systems/1/Bios/")
+	if err != nil {
+		return nil, err
+	}
+
+	settingsURL, err := c.biosSettingsURLHP()
+	if err != nil {
+		return nil, err
+	}
+
+	var payload interface{} = attrs
+	if x.Attributes != nil {
+		payload = map[string]interface{}{"Attributes": attrs}
+	}
+	data, _ := json.Marshal(payload)
+
+	resp, _, status, err := queryData(c, "PATCH", settingsURL, data)
+	if err != nil {
+		return nil, err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return nil, redfishError(resp, status)
+	}
+
+	return resp, nil
+}
+
+//CheckBiosDriftHP ... will compare the live BIOS with a baseline (see LoadBiosBaseline)
+// The report lists every drifted attribute and a plan staging only the settable differences.
+func (c *IloClient) CheckBiosDriftHP(desired BiosAttributes) (BiosDriftReport, error) {
+	reg, err := c.GetBiosRegistryHP()
+	if err != nil {
+		return BiosDriftReport{}, err
+	}
+
+	current, err := c.GetBiosAttributesHP()
+	if err != nil {
+		return BiosDriftReport{}, err
+	}
+
+	return compareBiosBaseline(reg, current, desired), nil
+}
+
+//ApplyBiosPlanHP ... will stage the plan attributes, with reboot the server is restarted to apply them
+func (c *IloClient) ApplyBiosPlanHP(plan BiosApplyPlan, reboot bool) (BiosSettingsResultHP, error) {
+	if len(plan.Attributes) == 0 {
+		return BiosSettingsResultHP{Message: "No Changes"}, nil
+	}
+
+	_result, err := c.SetBiosSettingsHP(plan.Attributes)
+	if err != nil {
+		return _result, err
+	}
+
+	if reboot {
+		if err := c.restartServerHP(); err != nil {
+			return _result, err
+		}
+		_result.RebootRequired = false
+	}
+
+	return _result, nil
+}
+
+//restartServerHP ... will gracefully restart the server so the staged BIOS settings are applied
+// iLO 4 has no GracefulRestart, the server is shut down with ShutdownHP and powered back on.
+func (c *IloClient) restartServerHP() error {
+	resp, _, _, err := queryData(c, "GET", c.Hostname+"/redfish/v1/Systems/1/", nil)
+	if err != nil {
+		return err
+	}
+
+	var x SystemInfoHP
+
+	json.Unmarshal(resp, &x)
+
+	allowed := x.Actions.ComputerSystemReset.ResetTypeRedfishAllowableValues
+	if len(allowed) > 0 && checkAllowable("ResetType", ResetGracefulRestart, allowed) == nil {
+		_, err := c.ResetServerHP(ResetGracefulRestart)
+		return err
+	}
+
+	_, err = c.ShutdownHP(context.Background(), 5*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.ResetServerHP(ResetOn)
+	return err
+}
+
+//ResetBiosHP ... will restore the BIOS defaults, they are loaded on the next boot
+// iLO 5 uses the Bios.ResetBios action, iLO 4 stages BaseConfig=default.
+// With reboot the server is gracefully restarted right away.
+func (c *IloClient) ResetBiosHP(reboot bool) (string, error) {
+	x, _, err := c.getBiosHP("/redfish/v1/Systems/1/Bios/")
+	if err != nil {
+		return "", err
+	}
+
+	if x.Actions.BiosResetBios.Target != "" {
+		url := c.Hostname + x.Actions.BiosResetBios.Target
+
+		resp, _, status, err := queryData(c, "POST", url, []byte(`{}`))
+		if err != nil {
+			return "", err
+		}
+		if status != 200 && status != 202 && status != 204 {
+			return "", redfishError(resp, status)
+		}
+	} else {
+		settingsURL, err := c.biosSettingsURLHP()
+		if err != nil {
+			return "", err
+		}
+
+		resp, _, status, err := queryData(c, "PATCH", settingsURL, []byte(`{"BaseConfig": "default"}`))
+		if err != nil {
+			return "", err
+		}
+		if status != 200 && status != 202 && status != 204 {
+			return "", redfishError(resp, status)
+		}
+	}
+
+	if !reboot {
+		return "BIOS Reset Pending Reboot", nil
+	}
+
+	if err := c.restartServerHP(); err != nil {
+		return "", err
+	}
+
+	return "BIOS Reset", nil
+}
+
+//ChangeBiosPasswordHP ... will set, change or clear (empty newPassword) a BIOS password
+// passwordName is BiosSetupPassword (administrator password) or BiosSystemPassword (power-on password),
+// oldPassword is empty when none is set. The change takes effect on the next reboot.
+func (c *IloClient) ChangeBiosPasswordHP(passwordName string, oldPassword string, newPassword string) (string, error) {
+	var name string
+
+	switch passwordName {
+	case BiosSetupPassword:
+		name = "AdministratorPassword"
+	case BiosSystemPassword:
+		name = "PowerOnPassword"
+	default:
+		return "", fmt.Errorf("unsupported BIOS password %q", passwordName)
+	}
+
+	x, _, err := c.getBiosHP("/redfish/v1/Systems/1/Bios/")
+	if err != nil {
+		return "", err
+	}
+
+	if x.Actions.BiosChangePassword.Target != "" {
+		url := c.Hostname + x.Actions.BiosChangePassword.Target
+
+		data, _ := json.Marshal(map[string]string{
+			"PasswordName": name,
+			"OldPassword":  oldPassword,
+			"NewPassword":  newPassword,
+		})
+
+		resp, _, status, err := queryData(c, "POST", url, data)
+		if err != nil {
+			return "", err
+		}
+		if status != 200 && status != 202 && status != 204 {
+			return "", redfishError(resp, status)
+		}
+
+		return "BIOS Password Changed", nil
+	}
+
+	// iLO 4 takes the passwords as settings, AdminPassword/OldAdminPassword and PowerOnPassword/OldPowerOnPassword
+	if name == "AdministratorPassword" {
+		name = "AdminPassword"
+	}
+	attrs := map[string]interface{}{
+		name:         newPassword,
+		"Old" + name: oldPassword,
+	}
+	if _, err := c.patchBiosSettingsHP(attrs); err != nil {
+		return "", err
+	}
+
+	return "BIOS Password Changed", nil
+}
+
+//getBiosHP ... will fetch a Bios (or Bios settings) resource and its attribute values
+func (c *IloClient) getBiosHP(path string) (BiosResourceHP, BiosAttributes, error) {
+	url := c.Hostname + path
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return BiosResourceHP{}, nil, err
+	}
+
+	var x BiosResourceHP
+
+	json.Unmarshal(resp, &x)
+
+	if x.Attributes != nil {
+		return x, x.Attributes, nil
+	}
+
+	// iLO 4 mixes the attributes with the resource properties
+	var attrs BiosAttributes
+
+	json.Unmarshal(resp, &attrs)
+
+	for k := range attrs {
+		switch k {
+		case "AttributeRegistry", "Description", "Id", "Modified", "Name", "Type", "links", "Links", "Oem", "SettingsResult", "Actions":
+			delete(attrs, k)
+		default:
+			if strings.HasPrefix(k, "@") {
+				delete(attrs, k)
+			}
+		}
+	}
+
+	return x, attrs, nil
 }
 
 //GetLicenseInfoHP ... will fetch the current License Details
@@ -663,3 +1911,432 @@ func (c *IloClient) GetEthernetInterfacesHP() ([]MACData, error) {
 	return _macData, nil
 
 }
+
+//raidLevelsHP ... Raid values of SmartStorageConfig for the RAIDType names used by VolumeCreateParams
+var raidLevelsHP = map[string]string{
+	"RAID0":  "Raid0",
+	"RAID1":  "Raid1",
+	"RAID5":  "Raid5",
+	"RAID6":  "Raid6",
+	"RAID10": "Raid10",
+	"RAID50": "Raid50",
+	"RAID60": "Raid60",
+}
+
+//GetSmartStorageHP ... will fetch the Smart Array controllers with their logical and physical drives
+func (c *IloClient) GetSmartStorageHP() ([]SmartArrayHP, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/"
+
+	members, err := c.smartStorageMembersHP(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var _controllers []SmartArrayHP
+
+	for _, m := range members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+m, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var x SmartStorageControllerHP
+
+		json.Unmarshal(resp, &x)
+
+		_result := SmartArrayHP{
+			ID:            x.ID,
+			Model:         x.Model,
+			Location:      x.Location,
+			SerialNumber:  x.SerialNumber,
+			Firmware:      x.FirmwareVersion.Current.VersionString,
+			Health:        x.Status.Health,
+			State:         x.Status.State,
+			CacheSizeMiB:  x.CacheMemorySizeMiB,
+			CacheHealth:   x.CacheModuleStatus.Health,
+			BatteryStatus: x.BackupPowerSourceStatus,
+		}
+
+		drives, err := c.smartStorageMembersHP(smartStorageLinkHP(c, x.Links.LogicalDrives))
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range drives {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+d, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var y SmartStorageLogicalDriveHP
+
+			json.Unmarshal(resp, &y)
+
+			_result.LogicalDrives = append(_result.LogicalDrives, LogicalDriveHP{
+				Number:          y.LogicalDriveNumber,
+				Name:            y.LogicalDriveName,
+				Raid:            y.Raid,
+				CapacityMiB:     y.CapacityMiB,
+				StripeSizeBytes: y.StripeSizeBytes,
+				VolumeID:        y.VolumeUniqueIdentifier,
+				Health:          y.Status.Health,
+				State:           y.Status.State,
+			})
+		}
+
+		drives, err = c.smartStorageMembersHP(smartStorageLinkHP(c, x.Links.PhysicalDrives))
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range drives {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+d, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var z SmartStorageDiskDriveHP
+
+			json.Unmarshal(resp, &z)
+
+			_result.PhysicalDrives = append(_result.PhysicalDrives, PhysicalDriveHP{
+				Location:      z.Location,
+				Model:         strings.TrimSpace(z.Model),
+				SerialNumber:  strings.TrimSpace(z.SerialNumber),
+				CapacityMiB:   z.CapacityMiB,
+				MediaType:     z.MediaType,
+				InterfaceType: z.InterfaceType,
+				Firmware:      z.FirmwareVersion.Current.VersionString,
+				Health:        z.Status.Health,
+				State:         z.Status.State,
+			})
+		}
+
+		_controllers = append(_controllers, _result)
+	}
+
+	return _controllers, nil
+}
+
+//CreateLogicalDriveHP ... will stage a new logical drive in SmartStorageConfig, it is created on the next reboot
+// config is the SmartStorageConfig resource of the controller ("" for the first one, SmartStorageConfig1 ...).
+// params.Drives are the physical drive locations (1I:1:1), cache policies Off/WriteThrough disable the
+// controller cache for the drive, params.SizeBytes has to be a whole number of GiB. Existing logical drives
+// are kept (DataGuard Strict). Only available on iLO 5.
+func (c *IloClient) CreateLogicalDriveHP(config string, params VolumeCreateParams) (string, error) {
+	raid, ok := raidLevelsHP[params.RAIDType]
+	if !ok {
+		return "", fmt.Errorf("unsupported RAID type %q", params.RAIDType)
+	}
+	if len(params.Drives) == 0 {
+		return "", errors.New("no drives given for the logical drive")
+	}
+	if params.SizeBytes%(1<<30) != 0 {
+		return "", fmt.Errorf("size %d is not a whole number of GiB", params.SizeBytes)
+	}
+
+	current, err := c.getSmartStorageConfigHP(config)
+	if err != nil {
+		return "", err
+	}
+
+	drive := map[string]interface{}{
+		"Raid":        raid,
+		"DataDrives":  params.Drives,
+		"Accelerator": "ControllerCache",
+	}
+	if params.Name != "" {
+		drive["LogicalDriveName"] = params.Name
+	}
+	if params.SizeBytes > 0 {
+		drive["CapacityGiB"] = params.SizeBytes / (1 << 30)
+	}
+	if params.StripeSizeBytes > 0 {
+		drive["StripSizeBytes"] = params.StripeSizeBytes
+	}
+	if params.ReadCachePolicy == "Off" && params.WriteCachePolicy == "WriteThrough" {
+		drive["Accelerator"] = "None"
+	}
+
+	payload := map[string]interface{}{
+		"DataGuard":     "Strict",
+		"LogicalDrives": append(current.LogicalDrives, drive),
+	}
+
+	if err := c.putSmartStorageConfigHP(config, payload); err != nil {
+		return "", err
+	}
+
+	return "Logical Drive Pending Reboot", nil
+}
+
+//DeleteLogicalDriveHP ... will stage the deletion of a logical drive (VolumeID of GetSmartStorageHP)
+// The drive is deleted on the next reboot. Only available on iLO 5.
+func (c *IloClient) DeleteLogicalDriveHP(config string, volumeID string) (string, error) {
+	payload := map[string]interface{}{
+		"DataGuard": "Permissive",
+		"LogicalDrives": []interface{}{
+			map[string]interface{}{
+				"Actions":                []interface{}{map[string]string{"Action": "LogicalDriveDelete"}},
+				"VolumeUniqueIdentifier": volumeID,
+			},
+		},
+	}
+
+	if err := c.putSmartStorageConfigHP(config, payload); err != nil {
+		return "", err
+	}
+
+	return "Logical Drive Delete Pending Reboot", nil
+}
+
+//getSmartStorageConfigHP ... will fetch the current configuration of a Smart Array controller
+func (c *IloClient) getSmartStorageConfigHP(config string) (SmartStorageConfigHP, error) {
+	if config == "" {
+		config = "SmartStorageConfig"
+	}
+	url := c.Hostname + "/redfish/v1/Systems/1/" + config + "/"
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return SmartStorageConfigHP{}, err
+	}
+
+	var x SmartStorageConfigHP
+
+	json.Unmarshal(resp, &x)
+
+	return x, nil
+}
+
+//putSmartStorageConfigHP ... will PUT a configuration to the settings of a Smart Array controller
+func (c *IloClient) putSmartStorageConfigHP(config string, payload map[string]interface{}) error {
+	if config == "" {
+		config = "SmartStorageConfig"
+	}
+	url := c.Hostname + "/redfish/v1/Systems/1/" + config + "/settings/"
+
+	data, _ := json.Marshal(payload)
+
+	resp, _, status, err := queryData(c, "PUT", url, data)
+	if err != nil {
+		return err
+	}
+	if status != 200 && status != 202 && status != 204 {
+		return redfishError(resp, status)
+	}
+
+	return nil
+}
+
+//smartStorageMembersHP ... will list the member links of a SmartStorage collection
+func (c *IloClient) smartStorageMembersHP(url string) ([]string, error) {
+	if url == "" {
+		return nil, nil
+	}
+
+	resp, _, _, err := queryData(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var x MemberCountHP
+
+	json.Unmarshal(resp, &x)
+
+	var members []string
+	for i := range x.Members {
+		members = append(members, x.Members[i].OdataID)
+	}
+
+	return members, nil
+}
+
+//smartStorageLinkHP ... will return the full URL of a SmartStorage link
+func smartStorageLinkHP(c *IloClient, l SmartStorageLinkHP) string {
+	if l.OdataID != "" {
+		return c.Hostname + l.OdataID
+	}
+	if l.Href != "" {
+		return c.Hostname + l.Href
+	}
+	return ""
+}
+
+//GetDriveHealthHP ... will fetch the wear, failure prediction and hot spare status of every drive
+// Smart Array drives are read from SmartStorage, direct attached (NVMe) drives from the
+// Storage collection of iLO 5. Drives listed in both are reported once.
+func (c *IloClient) GetDriveHealthHP() ([]DriveHealth, error) {
+	url := c.Hostname + "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/"
+
+	controllers, err := c.smartStorageMembersHP(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		_drives []DriveHealth
+		seen    = make(map[string]bool)
+	)
+
+	for _, m := range controllers {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+m, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var x SmartStorageControllerHP
+
+		json.Unmarshal(resp, &x)
+
+		drives, err := c.smartStorageMembersHP(smartStorageLinkHP(c, x.Links.PhysicalDrives))
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range drives {
+			resp, _, _, err := queryData(c, "GET", c.Hostname+d, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var z SmartStorageDiskDriveHP
+
+			json.Unmarshal(resp, &z)
+
+			_result := DriveHealth{
+				ID:            z.Location,
+				Controller:    x.Location,
+				Model:         strings.TrimSpace(z.Model),
+				SerialNumber:  strings.TrimSpace(z.SerialNumber),
+				MediaType:     z.MediaType,
+				Protocol:      z.InterfaceType,
+				CapacityBytes: z.CapacityMiB << 20,
+				Health:        z.Status.Health,
+				State:         z.Status.State,
+				Hotspare:      "None",
+				PowerOnHours:  z.PowerOnHours,
+			}
+			if z.DiskDriveUse == "Spare" {
+				_result.Hotspare = "Dedicated"
+			}
+			for _, r := range z.DiskDriveStatusReasons {
+				if strings.Contains(r, "Predictive") {
+					_result.FailurePredicted = true
+				}
+			}
+			if z.SSDEnduranceUtilizationPercentage != nil {
+				left := 100 - *z.SSDEnduranceUtilizationPercentage
+				_result.LifeLeftPercent = &left
+			}
+
+			seen[_result.SerialNumber] = true
+			_drives = append(_drives, _result)
+		}
+	}
+
+	found, err := getStorageDrives(c, c.Hostname+"/redfish/v1/Systems/1/Storage/")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range found {
+		h := driveHealth(f)
+		if h.SerialNumber != "" && seen[h.SerialNumber] {
+			continue
+		}
+		_drives = append(_drives, h)
+	}
+
+	return _drives, nil
+}
+
+//GetStorageControllersHP ... will fetch the cache and battery state of the Smart Array controllers
+// The batteries are shared by the controllers of the server. iLO does not report the write cache
+// mode or foreign configurations, WriteCache stays empty.
+func (c *IloClient) GetStorageControllersHP() ([]StorageControllerStatus, error) {
+	batteries, err := c.getStorageBatteriesHP()
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.Hostname + "/redfish/v1/Systems/1/SmartStorage/ArrayControllers/"
+
+	members, err := c.smartStorageMembersHP(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var _controllers []StorageControllerStatus
+
+	for _, m := range members {
+		resp, _, _, err := queryData(c, "GET", c.Hostname+m, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var x SmartStorageControllerHP
+
+		json.Unmarshal(resp, &x)
+
+		_result := StorageControllerStatus{
+			ID:           x.Location,
+			Model:        x.Model,
+			Firmware:     x.FirmwareVersion.Current.VersionString,
+			Health:       x.Status.Health,
+			State:        x.Status.State,
+			CacheSizeMiB: x.CacheMemorySizeMiB,
+			CacheHealth:  x.CacheModuleStatus.Health,
+			BatteryState: x.BackupPowerSourceStatus,
+		}
+
+		if x.BackupPowerSourceStatus == "Present" {
+			for _, b := range batteries {
+				health := b.Status.Health
+				if health == "" && b.Condition != "" {
+					health = strings.ToUpper(b.Condition)
+				}
+				if _result.BatteryHealth == "" || health != "OK" {
+					_result.BatteryHealth = health
+				}
+			}
+		}
+		_result.CacheDegraded = (_result.BatteryHealth != "" && _result.BatteryHealth != "OK") ||
+			(_result.CacheHealth != "" && _result.CacheHealth != "OK") ||
+			(_result.CacheSizeMiB > 0 && x.BackupPowerSourceStatus == "NotPresent")
+
+		_controllers = append(_controllers, _result)
+	}
+
+	return _controllers, nil
+}
+
+//getStorageBatteriesHP ... will fetch the Smart Storage batteries, Chassis/1 on iLO 5 and Systems/1 on iLO 4
+func (c *IloClient) getStorageBatteriesHP() ([]StorageBatteryHP, error) {
+	resp, _, _, err := queryData(c, "GET", c.Hostname+"/redfish/v1/Chassis/1/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var x struct {
+		Oem struct {
+			Hpe struct {
+				SmartStorageBattery []StorageBatteryHP `json:"SmartStorageBattery"`
+			} `json:"Hpe"`
+			Hp struct {
+				Battery []StorageBatteryHP `json:"Battery"`
+			} `json:"Hp"`
+		} `json:"Oem"`
+	}
+
+	json.Unmarshal(resp, &x)
+
+	if len(x.Oem.Hpe.SmartStorageBattery) > 0 {
+		return x.Oem.Hpe.SmartStorageBattery, nil
+	}
+
+	resp, _, _, err = queryData(c, "GET", c.Hostname+"/redfish/v1/Systems/1/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(resp, &x)
+
+	return x.Oem.Hp.Battery, nil
+}
@@ -0,0 +1,177 @@
+package redfishapi
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//firmwareExtensions ... file types served as firmware images (Dell DUPs, HPE fwpkg/flash files)
+var firmwareExtensions = map[string]bool{
+	".exe":    true,
+	".bin":    true,
+	".d9":     true,
+	".fwpkg":  true,
+	".signed": true,
+	".flash":  true,
+	".hpb":    true,
+}
+
+//FirmwareRepoServer ... Embeddable HTTP(S) file server the BMC can pull firmware from with SimpleUpdate
+type FirmwareRepoServer struct {
+	Dir      string // directory holding the images
+	Addr     string // listen address, ":0" picks a free port
+	Host     string // host or IP the BMC reaches this machine on, used in the ImageURIs, required unless Addr has an IP
+	CertFile string // serves HTTPS when both CertFile and KeyFile are set
+	KeyFile  string
+	Username string // enables basic auth when set
+	Password string
+
+	listener net.Listener
+	server   *http.Server
+}
+
+//NewFirmwareRepoServer ... Initializes a repo server for dir, call Start to serve it
+func NewFirmwareRepoServer(dir string, addr string, host string) *FirmwareRepoServer {
+
+	return &FirmwareRepoServer{
+		Dir:  dir,
+		Addr: addr,
+		Host: host,
+	}
+}
+
+//Start ... will listen on Addr and serve Dir in the background
+func (s *FirmwareRepoServer) Start() error {
+	info, err := os.Stat(s.Dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", s.Dir)
+	}
+
+	server := &http.Server{Handler: s.authHandler(http.FileServer(http.Dir(s.Dir)))}
+
+	// the key pair is loaded up front, ServeTLS errors would be lost in the goroutine
+	if s.tls() {
+		cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+		if err != nil {
+			return err
+		}
+		server.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	l, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	s.listener = l
+	s.server = server
+
+	if s.tls() {
+		go s.server.ServeTLS(l, "", "")
+	} else {
+		go s.server.Serve(l)
+	}
+
+	return nil
+}
+
+//Close ... will stop serving
+func (s *FirmwareRepoServer) Close() error {
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Close()
+}
+
+//URL ... will return the base URL of the running server as seen by the BMC
+func (s *FirmwareRepoServer) URL() (string, error) {
+	if s.listener == nil {
+		return "", errors.New("repo server is not started")
+	}
+
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	if s.Host != "" {
+		host = s.Host
+	} else if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		return "", errors.New("Host is required when listening on all addresses")
+	}
+
+	u := url.URL{Scheme: "http", Host: net.JoinHostPort(host, port)}
+	if s.tls() {
+		u.Scheme = "https"
+	}
+	if s.Username != "" {
+		u.User = url.UserPassword(s.Username, s.Password)
+	}
+
+	return u.String(), nil
+}
+
+//ImageURI ... will return the ImageURI for a file in Dir
+// The credentials are embedded in the URI when basic auth is enabled.
+func (s *FirmwareRepoServer) ImageURI(name string) (string, error) {
+	base, err := s.URL()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(filepath.Join(s.Dir, filepath.Clean("/"+name))); err != nil {
+		return "", err
+	}
+
+	return base + "/" + (&url.URL{Path: strings.TrimPrefix(filepath.ToSlash(name), "/")}).EscapedPath(), nil
+}
+
+//ImageURIs ... will return the ImageURIs of all the firmware images in Dir
+func (s *FirmwareRepoServer) ImageURIs() ([]string, error) {
+	files, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var uris []string
+
+	for _, f := range files {
+		if f.IsDir() || !firmwareExtensions[strings.ToLower(filepath.Ext(f.Name()))] {
+			continue
+		}
+		uri, err := s.ImageURI(f.Name())
+		if err != nil {
+			return nil, err
+		}
+		uris = append(uris, uri)
+	}
+
+	return uris, nil
+}
+
+func (s *FirmwareRepoServer) tls() bool {
+	return s.CertFile != "" && s.KeyFile != ""
+}
+
+//authHandler ... will require basic auth when a Username is set
+func (s *FirmwareRepoServer) authHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.Username != "" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || subtle.ConstantTimeCompare([]byte(user), []byte(s.Username)) != 1 ||
+				subtle.ConstantTimeCompare([]byte(pass), []byte(s.Password)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="firmware"`)
+				http.Error(w, StatusUnauthorized, http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1,5 +1,7 @@
 package redfishapi
 
+import "time"
+
 //Dell Based Structs
 
 //SysAttrDell ... System Attributes from the Redfish API
@@ -473,8 +475,8 @@ type IDRACAttributesData struct {
 	CurrentNIC_1_SharedNICScanTime                                       int         `json:"CurrentNIC.1.SharedNICScanTime"`
 	CurrentNIC_1_Speed                                                   string      `json:"CurrentNIC.1.Speed"`
 	CurrentNIC_1_VLanEnable                                              string      `json:"CurrentNIC.1.VLanEnable"`
-	CurrentNIC_1_VLanID                                                  int         `json:"CurrentNIC.1.VLanID"`
-	CurrentNIC_1_VLanPriority                                            int         `json:"CurrentNIC.1.VLanPriority"`
+	CurrentNIC_1_VLanID                                                  AttrInt     `json:"CurrentNIC.1.VLanID"`
+	CurrentNIC_1_VLanPriority                                            AttrInt     `json:"CurrentNIC.1.VLanPriority"`
 	CurrentNIC_1_VLanSetting                                             string      `json:"CurrentNIC.1.VLanSetting"`
 	DefaultCredentialMitigationConfigGroup_1_DefaultCredentialMitigation string      `json:"DefaultCredentialMitigationConfigGroup.1.DefaultCredentialMitigation"`
 	EmailAlert_1_Address                                                 string      `json:"EmailAlert.1.Address"`
@@ -633,9 +635,9 @@ type IDRACAttributesData struct {
 	NIC_1_SwitchConnection                                               string      `json:"NIC.1.SwitchConnection"`
 	NIC_1_SwitchPortConnection                                           string      `json:"NIC.1.SwitchPortConnection"`
 	NIC_1_VLanEnable                                                     string      `json:"NIC.1.VLanEnable"`
-	NIC_1_VLanID                                                         int         `json:"NIC.1.VLanID"`
+	NIC_1_VLanID                                                         AttrInt     `json:"NIC.1.VLanID"`
 	NIC_1_VLanPort                                                       string      `json:"NIC.1.VLanPort"`
-	NIC_1_VLanPriority                                                   int         `json:"NIC.1.VLanPriority"`
+	NIC_1_VLanPriority                                                   AttrInt     `json:"NIC.1.VLanPriority"`
 	NICStatic_1_DNSDomainFromDHCP                                        string      `json:"NICStatic.1.DNSDomainFromDHCP"`
 	NICStatic_1_DNSDomainName                                            string      `json:"NICStatic.1.DNSDomainName"`
 	NTPConfigGroup_1_NTP1                                                string      `json:"NTPConfigGroup.1.NTP1"`
@@ -1333,7 +1335,7 @@ type BiosAttrDell struct {
 type BiosAttributesData struct {
 	AcPwrRcvry                   string      `json:"AcPwrRcvry"`
 	AcPwrRcvryDelay              string      `json:"AcPwrRcvryDelay"`
-	AcPwrRcvryUserDelay          int         `json:"AcPwrRcvryUserDelay"`
+	AcPwrRcvryUserDelay          AttrInt     `json:"AcPwrRcvryUserDelay"`
 	AesNi                        string      `json:"AesNi"`
 	AssetTag                     string      `json:"AssetTag"`
 	AuthorizeDeviceFirmware      string      `json:"AuthorizeDeviceFirmware"`
@@ -2562,6 +2564,25 @@ type StorageDriveDetailsDell struct {
 	} `json:"Status"`
 }
 
+//UpdateServiceDell ... Update Service from the Redfish API
+type UpdateServiceDell struct {
+	OdataID              string `json:"@odata.id"`
+	HTTPPushURI          string `json:"HttpPushUri"`
+	MultipartHTTPPushURI string `json:"MultipartHttpPushUri"`
+	ServiceEnabled       bool   `json:"ServiceEnabled"`
+	Status               struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//BiosResourceDell ... Bios resource from the Redfish API with the attributes kept as a map
+type BiosResourceDell struct {
+	OdataID           string         `json:"@odata.id"`
+	AttributeRegistry string         `json:"AttributeRegistry"`
+	Attributes        BiosAttributes `json:"Attributes"`
+}
+
 //HP Based Structs
 
 //FirmwareInventoryHP ...
@@ -2903,7 +2924,7 @@ type MemberCountHP struct {
 	OdataType    string `json:"@odata.type"`
 	Description  string `json:"Description"`
 	Members      []struct {
-		OdataID string
+		OdataID string `json:"@odata.id"`
 	} `json:"Members"`
 	Members_odata_count int    `json:"Members@odata.count"`
 	Name                string `json:"Name"`
@@ -3548,7 +3569,7 @@ type BiosAttrHP struct {
 	PowerRegulator               string      `json:"PowerRegulator"`
 	PreBootNetwork               string      `json:"PreBootNetwork"`
 	ProcAes                      string      `json:"ProcAes"`
-	ProcCoreDisable              int         `json:"ProcCoreDisable"`
+	ProcCoreDisable              AttrInt     `json:"ProcCoreDisable"`
 	ProcHyperthreading           string      `json:"ProcHyperthreading"`
 	ProcNoExecute                string      `json:"ProcNoExecute"`
 	ProcTurbo                    string      `json:"ProcTurbo"`
@@ -3605,8 +3626,8 @@ type BiosAttrHP struct {
 	VirtualInstallDisk           string      `json:"VirtualInstallDisk"`
 	VirtualSerialPort            string      `json:"VirtualSerialPort"`
 	VlanControl                  string      `json:"VlanControl"`
-	VlanID                       int         `json:"VlanId"`
-	VlanPriority                 int         `json:"VlanPriority"`
+	VlanID                       AttrInt     `json:"VlanId"`
+	VlanPriority                 AttrInt     `json:"VlanPriority"`
 	WakeOnLan                    string      `json:"WakeOnLan"`
 	Links                        struct {
 		Self struct {
@@ -3703,6 +3724,203 @@ type PCISlotsInfoHP struct {
 	} `json:"links"`
 }
 
+//UpdateServiceHP ... Update Service from the Redfish API
+type UpdateServiceHP struct {
+	OdataID              string `json:"@odata.id"`
+	HTTPPushURI          string `json:"HttpPushUri"`
+	MultipartHTTPPushURI string `json:"MultipartHttpPushUri"`
+	ServiceEnabled       bool   `json:"ServiceEnabled"`
+	Oem                  struct {
+		Hpe struct {
+			CurrentTime          string `json:"CurrentTime"`
+			FlashProgressPercent int    `json:"FlashProgressPercent"`
+			Result               struct {
+				MessageID string `json:"MessageId"`
+			} `json:"Result"`
+			State        string `json:"State"`
+			UpdateTarget string `json:"UpdateTarget"`
+		} `json:"Hpe"`
+	} `json:"Oem"`
+}
+
+//ComponentRepositoryHP ... Component from the iLO Component Repository
+type ComponentRepositoryHP struct {
+	OdataID       string   `json:"@odata.id"`
+	Configuration string   `json:"Configuration"`
+	Created       string   `json:"Created"`
+	Criticality   string   `json:"Criticality"`
+	Filename      string   `json:"Filename"`
+	ID            string   `json:"Id"`
+	Locked        bool     `json:"Locked"`
+	Name          string   `json:"Name"`
+	SizeBytes     int64    `json:"SizeBytes"`
+	Targets       []string `json:"Targets"`
+	Version       string   `json:"Version"`
+}
+
+//InstallSetHP ... Install Set from the iLO Update Service
+type InstallSetHP struct {
+	OdataID     string `json:"@odata.id"`
+	Created     string `json:"Created"`
+	Description string `json:"Description"`
+	ID          string `json:"Id"`
+	IsRecovery  bool   `json:"IsRecovery"`
+	Name        string `json:"Name"`
+	Sequence    []struct {
+		Command     string   `json:"Command"`
+		Filename    string   `json:"Filename"`
+		Name        string   `json:"Name"`
+		UpdatableBy []string `json:"UpdatableBy"`
+	} `json:"Sequence"`
+}
+
+//UpdateTaskHP ... Task from the iLO Update Task Queue
+type UpdateTaskHP struct {
+	OdataID  string `json:"@odata.id"`
+	Command  string `json:"Command"`
+	Created  string `json:"Created"`
+	Filename string `json:"Filename"`
+	ID       string `json:"Id"`
+	Modified string `json:"Modified"`
+	Name     string `json:"Name"`
+	Result   struct {
+		MessageID string `json:"MessageId"`
+	} `json:"Result"`
+	State       string   `json:"State"`
+	UpdatableBy []string `json:"UpdatableBy"`
+}
+
+//BootSettingsHP ... Persistent boot order from the bios/boot resource
+type BootSettingsHP struct {
+	RedfishSettings struct {
+		SettingsObject struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"SettingsObject"`
+	} `json:"@Redfish.Settings"`
+	BootSources []struct {
+		BootString           string `json:"BootString"`
+		CorrelatableID       string `json:"CorrelatableID"`
+		StructuredBootString string `json:"StructuredBootString"`
+		UEFIDevicePath       string `json:"UEFIDevicePath"`
+	} `json:"BootSources"`
+	PersistentBootConfigOrder []string `json:"PersistentBootConfigOrder"`
+}
+
+//SmartStorageLinkHP ... Link of the SmartStorage resources, iLO 4 uses href and iLO 5 @odata.id
+type SmartStorageLinkHP struct {
+	OdataID string `json:"@odata.id"`
+	Href    string `json:"href"`
+}
+
+//SmartStorageControllerHP ... Smart Array controller from /Systems/1/SmartStorage/ArrayControllers
+type SmartStorageControllerHP struct {
+	ID                      string `json:"Id"`
+	Model                   string `json:"Model"`
+	Location                string `json:"Location"`
+	SerialNumber            string `json:"SerialNumber"`
+	CacheMemorySizeMiB      int    `json:"CacheMemorySizeMiB"`
+	BackupPowerSourceStatus string `json:"BackupPowerSourceStatus"`
+	CacheModuleStatus       struct {
+		Health string `json:"Health"`
+	} `json:"CacheModuleStatus"`
+	FirmwareVersion struct {
+		Current struct {
+			VersionString string `json:"VersionString"`
+		} `json:"Current"`
+	} `json:"FirmwareVersion"`
+	Links struct {
+		LogicalDrives  SmartStorageLinkHP `json:"LogicalDrives"`
+		PhysicalDrives SmartStorageLinkHP `json:"PhysicalDrives"`
+	} `json:"Links"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//SmartStorageLogicalDriveHP ... Logical drive of a Smart Array controller
+type SmartStorageLogicalDriveHP struct {
+	LogicalDriveNumber     int    `json:"LogicalDriveNumber"`
+	LogicalDriveName       string `json:"LogicalDriveName"`
+	Raid                   string `json:"Raid"`
+	CapacityMiB            int64  `json:"CapacityMiB"`
+	StripeSizeBytes        int64  `json:"StripeSizeBytes"`
+	VolumeUniqueIdentifier string `json:"VolumeUniqueIdentifier"`
+	Status                 struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//SmartStorageDiskDriveHP ... Physical drive of a Smart Array controller
+type SmartStorageDiskDriveHP struct {
+	Location                          string   `json:"Location"`
+	Model                             string   `json:"Model"`
+	SerialNumber                      string   `json:"SerialNumber"`
+	CapacityMiB                       int64    `json:"CapacityMiB"`
+	MediaType                         string   `json:"MediaType"`
+	InterfaceType                     string   `json:"InterfaceType"`
+	DiskDriveUse                      string   `json:"DiskDriveUse"`
+	DiskDriveStatusReasons            []string `json:"DiskDriveStatusReasons"`
+	SSDEnduranceUtilizationPercentage *float64 `json:"SSDEnduranceUtilizationPercentage"`
+	PowerOnHours                      *float64 `json:"PowerOnHours"`
+	FirmwareVersion                   struct {
+		Current struct {
+			VersionString string `json:"VersionString"`
+		} `json:"Current"`
+	} `json:"FirmwareVersion"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//SmartStorageConfigHP ... Configuration of a Smart Array controller from /Systems/1/SmartStorageConfig (iLO 5)
+type SmartStorageConfigHP struct {
+	Location      string                   `json:"Location"`
+	LogicalDrives []map[string]interface{} `json:"LogicalDrives"`
+}
+
+//StorageBatteryHP ... Smart Storage battery from Chassis/1 (iLO 5, Oem.Hpe.SmartStorageBattery) or Systems/1 (iLO 4, Oem.Hp.Battery)
+type StorageBatteryHP struct {
+	Index     int    `json:"Index"`
+	Model     string `json:"Model"`
+	Condition string `json:"Condition"`
+	Status    struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//BiosResourceHP ... Bios resource from the Redfish API (iLO 4 returns the attributes at the top level)
+type BiosResourceHP struct {
+	OdataID         string `json:"@odata.id"`
+	RedfishSettings struct {
+		SettingsObject struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"SettingsObject"`
+	} `json:"@Redfish.Settings"`
+	Actions struct {
+		BiosChangePassword struct {
+			Target string `json:"target"`
+		} `json:"#Bios.ChangePassword"`
+		BiosResetBios struct {
+			Target string `json:"target"`
+		} `json:"#Bios.ResetBios"`
+	} `json:"Actions"`
+	Oem struct {
+		Hpe struct {
+			Links struct {
+				Boot struct {
+					OdataID string `json:"@odata.id"`
+				} `json:"Boot"`
+			} `json:"Links"`
+		} `json:"Hpe"`
+	} `json:"Oem"`
+	AttributeRegistry string         `json:"AttributeRegistry"`
+	Attributes        BiosAttributes `json:"Attributes"`
+}
+
 //Custom Structs
 
 //HealthList ...
@@ -4156,3 +4374,743 @@ type ImageStatusDell struct {
 	Name                   string   `json:"Name"`
 	WriteProtected         bool     `json:"WriteProtected"`
 }
+
+//RedfishErrorResponse ... Error body returned by the Redfish API on a failed request
+type RedfishErrorResponse struct {
+	Error struct {
+		Code                string `json:"code"`
+		Message             string `json:"message"`
+		MessageExtendedInfo []struct {
+			Message           string   `json:"Message"`
+			MessageID         string   `json:"MessageId"`
+			MessageArgs       []string `json:"MessageArgs"`
+			RelatedProperties []string `json:"RelatedProperties"`
+			Resolution        string   `json:"Resolution"`
+			Severity          string   `json:"Severity"`
+		} `json:"@Message.ExtendedInfo"`
+	} `json:"error"`
+}
+
+//MaintenanceWindow ... Time window in which scheduled jobs are allowed to run
+type MaintenanceWindow struct {
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+//FirmwareUpdateComponentDell ... A staged firmware package selected for an update plan
+type FirmwareUpdateComponentDell struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Version             string `json:"version"`
+	ComponentID         string `json:"component_id"`
+	SoftwareIdentityURI string `json:"software_identity_uri"`
+}
+
+//FirmwareUpdatePlanDell ... Ordered set of firmware components and how to install them
+type FirmwareUpdatePlanDell struct {
+	Components        []FirmwareUpdateComponentDell `json:"components"`
+	InstallUpon       string                        `json:"install_upon"`
+	MaintenanceWindow *MaintenanceWindow            `json:"maintenance_window,omitempty"`
+	PollInterval      time.Duration                 `json:"poll_interval"`
+	Timeout           time.Duration                 `json:"timeout"`
+}
+
+//FirmwareUpdateResult ... Outcome of a single component update
+type FirmwareUpdateResult struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	TaskURL  string `json:"task_url"`
+	JobState string `json:"job_state"`
+	Message  string `json:"message"`
+	Success  bool   `json:"success"`
+}
+
+//UploadProgress ... Called with the bytes sent so far and the total size (-1 when unknown)
+type UploadProgress func(sent int64, total int64)
+
+//FirmwareUpdateParameters ... UpdateParameters part of a MultipartHttpPushUri upload
+type FirmwareUpdateParameters struct {
+	Targets   []string    `json:"Targets,omitempty"`
+	ApplyTime string      `json:"@Redfish.OperationApplyTime,omitempty"`
+	Oem       interface{} `json:"Oem,omitempty"`
+}
+
+//FirmwareUploadOptionsHP ... Where iLO should put an uploaded image
+type FirmwareUploadOptionsHP struct {
+	UpdateRepository bool `json:"update_repository"`
+	UpdateTarget     bool `json:"update_target"`
+}
+
+//InstallSetItemHP ... Component to flash as part of an install set
+type InstallSetItemHP struct {
+	Filename    string   `json:"filename"`
+	UpdatableBy []string `json:"updatable_by"`
+}
+
+//FlashStatusHP ... Flash progress reported by the iLO Update Service
+type FlashStatusHP struct {
+	State                string `json:"state"`
+	FlashProgressPercent int    `json:"flash_progress_percent"`
+	Result               string `json:"result"`
+	UpdateTarget         string `json:"update_target"`
+}
+
+//SoftwareInventoryHP ... Member of the iLO 5 /UpdateService/FirmwareInventory collection
+type SoftwareInventoryHP struct {
+	OdataID string `json:"@odata.id"`
+	ID      string `json:"Id"`
+	Name    string `json:"Name"`
+	Version string `json:"Version"`
+}
+
+//RollbackFirmware ... Component with a previous firmware image to roll back to
+type RollbackFirmware struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	InstalledVersion string `json:"installed_version"`
+	RollbackVersion  string `json:"rollback_version"`
+	RollbackURI      string `json:"rollback_uri"`
+}
+
+//RegistryFile ... Registry file entry pointing at the registry itself
+// iLO 4 returns the Uri as an {"extref": ...} object
+type RegistryFile struct {
+	ID       string `json:"Id"`
+	Location []struct {
+		Language string      `json:"Language"`
+		URI      interface{} `json:"Uri"`
+	} `json:"Location"`
+}
+
+//BiosRegistry ... BIOS attribute registry
+type BiosRegistry struct {
+	ID              string `json:"Id"`
+	RegistryVersion string `json:"RegistryVersion"`
+	RegistryEntries struct {
+		Attributes   []BiosRegistryAttribute  `json:"Attributes"`
+		Dependencies []BiosRegistryDependency `json:"Dependencies"`
+	} `json:"RegistryEntries"`
+}
+
+//BiosRegistryAttribute ... Definition of a single BIOS attribute
+// iLO 4 uses Name instead of AttributeName and Enum instead of Enumeration
+type BiosRegistryAttribute struct {
+	AttributeName   string   `json:"AttributeName"`
+	Name            string   `json:"Name"`
+	DisplayName     string   `json:"DisplayName"`
+	Type            string   `json:"Type"`
+	ReadOnly        bool     `json:"ReadOnly"`
+	Hidden          bool     `json:"Hidden"`
+	LowerBound      *float64 `json:"LowerBound"`
+	UpperBound      *float64 `json:"UpperBound"`
+	ScalarIncrement *float64 `json:"ScalarIncrement"`
+	MinLength       *int     `json:"MinLength"`
+	MaxLength       *int     `json:"MaxLength"`
+	ValueExpression string   `json:"ValueExpression"`
+	Value           []struct {
+		ValueName        string `json:"ValueName"`
+		ValueDisplayName string `json:"ValueDisplayName"`
+	} `json:"Value"`
+}
+
+//BiosRegistryDependency ... Condition under which an attribute property changes
+type BiosRegistryDependency struct {
+	DependencyFor string `json:"DependencyFor"`
+	Type          string `json:"Type"`
+	Dependency    struct {
+		MapFrom []struct {
+			MapFromAttribute string      `json:"MapFromAttribute"`
+			MapFromCondition string      `json:"MapFromCondition"`
+			MapFromProperty  string      `json:"MapFromProperty"`
+			MapFromValue     interface{} `json:"MapFromValue"`
+			MapTerms         string      `json:"MapTerms"`
+		} `json:"MapFrom"`
+		MapToAttribute string      `json:"MapToAttribute"`
+		MapToProperty  string      `json:"MapToProperty"`
+		MapToValue     interface{} `json:"MapToValue"`
+	} `json:"Dependency"`
+}
+
+//BiosAttributeError ... Reason a BIOS attribute value was rejected
+type BiosAttributeError struct {
+	Attribute string `json:"attribute"`
+	Message   string `json:"message"`
+}
+
+//BiosValidationError ... All the attribute errors found while validating BIOS settings
+type BiosValidationError []BiosAttributeError
+
+//BiosPendingValue ... Current and staged value of a BIOS attribute
+type BiosPendingValue struct {
+	Attribute string      `json:"attribute"`
+	Current   interface{} `json:"current"`
+	Pending   interface{} `json:"pending"`
+}
+
+//BiosSettingsResultHP ... Outcome of staging HP BIOS settings
+type BiosSettingsResultHP struct {
+	Message        string             `json:"message"`
+	RebootRequired bool               `json:"reboot_required"`
+	Pending        []BiosPendingValue `json:"pending"`
+}
+
+//BiosAttributes ... Every BIOS attribute reported by the server, keyed by attribute name
+type BiosAttributes map[string]interface{}
+
+//BiosPendingSettings ... BIOS attributes staged for the next reboot and the job applying them
+type BiosPendingSettings struct {
+	Pending []BiosPendingValue `json:"pending"`
+	Job     *JobStatusDell     `json:"job,omitempty"`
+}
+
+//BiosDrift ... Attribute whose live value differs from the baseline
+type BiosDrift struct {
+	Attribute string      `json:"attribute"`
+	Expected  interface{} `json:"expected"`
+	Actual    interface{} `json:"actual"`
+	Settable  bool        `json:"settable"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+//BiosApplyPlan ... Attributes to stage to bring the BIOS back to the baseline
+type BiosApplyPlan struct {
+	Attributes     map[string]interface{} `json:"attributes"`
+	RequiresJob    bool                   `json:"requires_job"`
+	RequiresReboot bool                   `json:"requires_reboot"`
+}
+
+//BiosDriftReport ... Drift between the live BIOS and a baseline, with the plan to fix it
+type BiosDriftReport struct {
+	Drift []BiosDrift   `json:"drift"`
+	Plan  BiosApplyPlan `json:"plan"`
+}
+
+//SystemBoot ... Boot property of a ComputerSystem, iLO 4 lists the allowed targets in the *Supported properties
+type SystemBoot struct {
+	BootOptions struct {
+		OdataID string `json:"@odata.id"`
+	} `json:"BootOptions"`
+	BootOrder                                   []string `json:"BootOrder"`
+	BootSourceOverrideEnabled                   string   `json:"BootSourceOverrideEnabled"`
+	BootSourceOverrideMode                      string   `json:"BootSourceOverrideMode"`
+	BootSourceOverrideModeAllowableValues       []string `json:"BootSourceOverrideMode@Redfish.AllowableValues"`
+	BootSourceOverrideTarget                    string   `json:"BootSourceOverrideTarget"`
+	BootSourceOverrideTargetAllowableValues     []string `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+	BootSourceOverrideSupported                 []string `json:"BootSourceOverrideSupported"`
+	UefiTargetBootSourceOverride                string   `json:"UefiTargetBootSourceOverride"`
+	UefiTargetBootSourceOverrideAllowableValues []string `json:"UefiTargetBootSourceOverride@Redfish.AllowableValues"`
+	UefiTargetBootSourceOverrideSupported       []string `json:"UefiTargetBootSourceOverrideSupported"`
+}
+
+//BootOption ... Member of the BootOptions collection of a ComputerSystem
+type BootOption struct {
+	OdataID             string `json:"@odata.id"`
+	ID                  string `json:"Id"`
+	Name                string `json:"Name"`
+	DisplayName         string `json:"DisplayName"`
+	BootOptionEnabled   *bool  `json:"BootOptionEnabled"`
+	BootOptionReference string `json:"BootOptionReference"`
+	UefiDevicePath      string `json:"UefiDevicePath"`
+}
+
+//HTTPBootConfig ... UEFI HTTP boot device settings, the address fields are left empty to use DHCP
+type HTTPBootConfig struct {
+	URI       string `json:"uri"`
+	Interface string `json:"interface"` // NIC FQDD on Dell (NIC.Integrated.1-1-1), PreBootNetwork value on HP (Auto, EmbNicPort1 ...)
+	IPMode    string `json:"ip_mode"`   // IPv4 or IPv6
+	Address   string `json:"address,omitempty"`
+	Netmask   string `json:"netmask,omitempty"`
+	Gateway   string `json:"gateway,omitempty"`
+	DNS       string `json:"dns,omitempty"`
+}
+
+//ISCSIBootConfig ... iSCSI initiator and target settings
+// The initiator address fields are left empty to use DHCP, an empty TargetName gets the target from DHCP.
+type ISCSIBootConfig struct {
+	Interface            string `json:"interface,omitempty"`       // NIC FQDD, used by the Dell BIOS attributes
+	IPAddressType        string `json:"ip_address_type,omitempty"` // IPv4 (default) or IPv6
+	InitiatorName        string `json:"initiator_name"`
+	InitiatorAddress     string `json:"initiator_address,omitempty"`
+	InitiatorNetmask     string `json:"initiator_netmask,omitempty"`
+	InitiatorGateway     string `json:"initiator_gateway,omitempty"`
+	TargetName           string `json:"target_name"`
+	TargetAddress        string `json:"target_address"`
+	TargetPort           int    `json:"target_port"`
+	LUN                  int    `json:"lun"`
+	AuthenticationMethod string `json:"authentication_method,omitempty"` // None, CHAP or MutualCHAP
+	CHAPUsername         string `json:"chap_username,omitempty"`
+	CHAPSecret           string `json:"chap_secret,omitempty"`
+	MutualCHAPUsername   string `json:"mutual_chap_username,omitempty"` // credentials the target answers with, MutualCHAP only
+	MutualCHAPSecret     string `json:"mutual_chap_secret,omitempty"`
+}
+
+//PowerResource ... Power resource of a chassis, iLO 4 reports the consumption at the top level
+type PowerResource struct {
+	PowerConsumedWatts float64 `json:"PowerConsumedWatts"`
+	PowerCapacityWatts float64 `json:"PowerCapacityWatts"`
+	PowerLimit         struct {
+		LimitInWatts *float64 `json:"LimitInWatts"`
+	} `json:"PowerLimit"`
+	PowerMetrics PowerMetricsResource `json:"PowerMetrics"`
+	PowerControl []struct {
+		MemberID           string  `json:"MemberId"`
+		PowerConsumedWatts float64 `json:"PowerConsumedWatts"`
+		PowerCapacityWatts float64 `json:"PowerCapacityWatts"`
+		PowerLimit         struct {
+			LimitInWatts *float64 `json:"LimitInWatts"`
+		} `json:"PowerLimit"`
+		PowerMetrics PowerMetricsResource `json:"PowerMetrics"`
+	} `json:"PowerControl"`
+	PowerSupplies []struct {
+		MemberID             string  `json:"MemberId"`
+		Name                 string  `json:"Name"`
+		PowerInputWatts      float64 `json:"PowerInputWatts"`
+		PowerOutputWatts     float64 `json:"PowerOutputWatts"`
+		LastPowerOutputWatts float64 `json:"LastPowerOutputWatts"`
+		PowerCapacityWatts   float64 `json:"PowerCapacityWatts"`
+		LineInputVoltage     float64 `json:"LineInputVoltage"`
+		EfficiencyPercent    float64 `json:"EfficiencyPercent"`
+		Status               struct {
+			Health string `json:"Health"`
+			State  string `json:"State"`
+		} `json:"Status"`
+	} `json:"PowerSupplies"`
+}
+
+//PowerMetricsResource ... Consumption statistics over the last interval
+type PowerMetricsResource struct {
+	AverageConsumedWatts float64 `json:"AverageConsumedWatts"`
+	IntervalInMin        int     `json:"IntervalInMin"`
+	MaxConsumedWatts     float64 `json:"MaxConsumedWatts"`
+	MinConsumedWatts     float64 `json:"MinConsumedWatts"`
+}
+
+//PowerMetrics ... Power consumption of the server and its power supplies
+type PowerMetrics struct {
+	ConsumedWatts        float64              `json:"consumed_watts"`
+	CapacityWatts        float64              `json:"capacity_watts"`
+	MinConsumedWatts     float64              `json:"min_consumed_watts"`
+	MaxConsumedWatts     float64              `json:"max_consumed_watts"`
+	AverageConsumedWatts float64              `json:"average_consumed_watts"`
+	IntervalInMin        int                  `json:"interval_in_min"`
+	LimitInWatts         float64              `json:"limit_in_watts"` // 0 when no power cap is set
+	PowerSupplies        []PowerSupplyMetrics `json:"power_supplies"`
+}
+
+//PowerSupplyMetrics ... Readings of a power supply
+type PowerSupplyMetrics struct {
+	Name              string  `json:"name"`
+	Health            string  `json:"health"`
+	State             string  `json:"state"`
+	InputWatts        float64 `json:"input_watts"`
+	OutputWatts       float64 `json:"output_watts"`
+	CapacityWatts     float64 `json:"capacity_watts"`
+	LineInputVoltage  float64 `json:"line_input_voltage"`
+	EfficiencyPercent float64 `json:"efficiency_percent"`
+}
+
+//ThermalResource ... Thermal resource of a chassis
+// iLO 4 reports CurrentReading, Units, Context and FanName instead of the standard properties.
+type ThermalResource struct {
+	Temperatures []struct {
+		Name                      string   `json:"Name"`
+		ReadingCelsius            *float64 `json:"ReadingCelsius"`
+		CurrentReading            *float64 `json:"CurrentReading"`
+		PhysicalContext           string   `json:"PhysicalContext"`
+		Context                   string   `json:"Context"`
+		UpperThresholdNonCritical *float64 `json:"UpperThresholdNonCritical"`
+		UpperThresholdCritical    *float64 `json:"UpperThresholdCritical"`
+		UpperThresholdFatal       *float64 `json:"UpperThresholdFatal"`
+		LowerThresholdNonCritical *float64 `json:"LowerThresholdNonCritical"`
+		LowerThresholdCritical    *float64 `json:"LowerThresholdCritical"`
+		LowerThresholdFatal       *float64 `json:"LowerThresholdFatal"`
+		Status                    struct {
+			Health string `json:"Health"`
+			State  string `json:"State"`
+		} `json:"Status"`
+	} `json:"Temperatures"`
+	Fans []struct {
+		Name                      string   `json:"Name"`
+		FanName                   string   `json:"FanName"`
+		Reading                   *float64 `json:"Reading"`
+		CurrentReading            *float64 `json:"CurrentReading"`
+		ReadingUnits              string   `json:"ReadingUnits"`
+		Units                     string   `json:"Units"`
+		PhysicalContext           string   `json:"PhysicalContext"`
+		Context                   string   `json:"Context"`
+		UpperThresholdNonCritical *float64 `json:"UpperThresholdNonCritical"`
+		UpperThresholdCritical    *float64 `json:"UpperThresholdCritical"`
+		UpperThresholdFatal       *float64 `json:"UpperThresholdFatal"`
+		LowerThresholdNonCritical *float64 `json:"LowerThresholdNonCritical"`
+		LowerThresholdCritical    *float64 `json:"LowerThresholdCritical"`
+		LowerThresholdFatal       *float64 `json:"LowerThresholdFatal"`
+		Status                    struct {
+			Health string `json:"Health"`
+			State  string `json:"State"`
+		} `json:"Status"`
+	} `json:"Fans"`
+}
+
+//SensorThresholds ... Thresholds of a sensor, nil when the BMC does not report them
+type SensorThresholds struct {
+	UpperNonCritical *float64 `json:"upper_non_critical,omitempty"`
+	UpperCritical    *float64 `json:"upper_critical,omitempty"`
+	UpperFatal       *float64 `json:"upper_fatal,omitempty"`
+	LowerNonCritical *float64 `json:"lower_non_critical,omitempty"`
+	LowerCritical    *float64 `json:"lower_critical,omitempty"`
+	LowerFatal       *float64 `json:"lower_fatal,omitempty"`
+}
+
+//SensorReading ... Temperature (Celsius) or fan speed reading
+type SensorReading struct {
+	Name            string           `json:"name"`
+	Reading         *float64         `json:"reading"`
+	Units           string           `json:"units"`
+	PhysicalContext string           `json:"physical_context"`
+	Health          string           `json:"health"`
+	State           string           `json:"state"`
+	Thresholds      SensorThresholds `json:"thresholds"`
+}
+
+//ThermalMetrics ... Temperature and fan readings of a chassis
+type ThermalMetrics struct {
+	Temperatures []SensorReading `json:"temperatures"`
+	Fans         []SensorReading `json:"fans"`
+}
+
+//MemoryResource ... Memory (DIMM) resource of a system
+// iLO 4 reports SocketLocator, SizeMB, MaximumFrequencyMHz, DIMMType, Rank and DIMMStatus instead.
+type MemoryResource struct {
+	ID                  string  `json:"Id"`
+	Name                string  `json:"Name"`
+	DeviceLocator       string  `json:"DeviceLocator"`
+	SocketLocator       string  `json:"SocketLocator"`
+	CapacityMiB         int     `json:"CapacityMiB"`
+	SizeMB              int     `json:"SizeMB"`
+	OperatingSpeedMhz   int     `json:"OperatingSpeedMhz"`
+	MaximumFrequencyMHz int     `json:"MaximumFrequencyMHz"`
+	MemoryDeviceType    string  `json:"MemoryDeviceType"`
+	DIMMType            string  `json:"DIMMType"`
+	Manufacturer        string  `json:"Manufacturer"`
+	PartNumber          string  `json:"PartNumber"`
+	SerialNumber        string  `json:"SerialNumber"`
+	RankCount           int     `json:"RankCount"`
+	Rank                int     `json:"Rank"`
+	DIMMStatus          string  `json:"DIMMStatus"`
+	Metrics             Members `json:"Metrics"`
+	Oem                 struct {
+		Hpe struct {
+			DIMMStatus string `json:"DIMMStatus"`
+		} `json:"Hpe"`
+	} `json:"Oem"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//MemoryMetricsResource ... Error counters of a DIMM
+type MemoryMetricsResource struct {
+	HealthData struct {
+		AlarmTrips struct {
+			CorrectableECCError   bool `json:"CorrectableECCError"`
+			UncorrectableECCError bool `json:"UncorrectableECCError"`
+		} `json:"AlarmTrips"`
+	} `json:"HealthData"`
+}
+
+//MemoryModule ... Installed DIMM
+type MemoryModule struct {
+	Slot                string `json:"slot"`
+	CapacityMiB         int    `json:"capacity_mib"`
+	SpeedMHz            int    `json:"speed_mhz"`
+	Type                string `json:"type"`
+	Manufacturer        string `json:"manufacturer"`
+	PartNumber          string `json:"part_number"`
+	SerialNumber        string `json:"serial_number"`
+	RankCount           int    `json:"rank_count"`
+	Health              string `json:"health"`
+	State               string `json:"state"`
+	Status              string `json:"status,omitempty"` // HPE DIMMStatus
+	CorrectableErrors   bool   `json:"correctable_errors"`
+	UncorrectableErrors bool   `json:"uncorrectable_errors"`
+}
+
+//ProcessorResource ... Processor resource of a system with the vendor specific extras
+type ProcessorResource struct {
+	ID           string `json:"Id"`
+	Socket       string `json:"Socket"`
+	Model        string `json:"Model"`
+	Manufacturer string `json:"Manufacturer"`
+	TotalCores   int    `json:"TotalCores"`
+	TotalThreads int    `json:"TotalThreads"`
+	MaxSpeedMHz  int    `json:"MaxSpeedMHz"`
+	ProcessorID  struct {
+		MicrocodeInfo string `json:"MicrocodeInfo"`
+	} `json:"ProcessorId"`
+	Oem struct {
+		Dell struct {
+			DellProcessor struct {
+				CurrentClockSpeedMhz int `json:"CurrentClockSpeedMhz"`
+				CoreCount            int `json:"CoreCount"`
+			} `json:"DellProcessor"`
+		} `json:"Dell"`
+		Hp  ProcessorOemHP `json:"Hp"`
+		Hpe ProcessorOemHP `json:"Hpe"`
+	} `json:"Oem"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//ProcessorOemHP ... HP (iLO 4) and Hpe (iLO 5) processor extras
+type ProcessorOemHP struct {
+	ConfigStatus struct {
+		State string `json:"State"`
+	} `json:"ConfigStatus"`
+	CoresEnabled     int `json:"CoresEnabled"`
+	RatedSpeedMHz    int `json:"RatedSpeedMHz"`
+	MicrocodePatches []struct {
+		PatchID string `json:"PatchId"`
+	} `json:"MicrocodePatches"`
+}
+
+//ProcessorData ... Installed processor
+type ProcessorData struct {
+	Socket       string `json:"socket"`
+	Model        string `json:"model"`
+	Manufacturer string `json:"manufacturer"`
+	TotalCores   int    `json:"total_cores"`
+	EnabledCores int    `json:"enabled_cores"`
+	TotalThreads int    `json:"total_threads"`
+	MaxSpeedMHz  int    `json:"max_speed_mhz"`
+	SpeedMHz     int    `json:"speed_mhz"` // current clock on Dell, rated speed on HP
+	Microcode    string `json:"microcode"`
+	Health       string `json:"health"`
+	State        string `json:"state"`
+}
+
+//VolumeCreateParams ... Virtual disk to create on a RAID controller
+type VolumeCreateParams struct {
+	Name             string   `json:"name"`
+	RAIDType         string   `json:"raid_type"`          // RAID0, RAID1, RAID5, RAID6, RAID10, RAID50, RAID60
+	Drives           []string `json:"drives"`             // drive IDs or @odata.id paths
+	SizeBytes        int64    `json:"size_bytes"`         // 0 uses all the space of the drives
+	StripeSizeBytes  int64    `json:"stripe_size_bytes"`  // 0 keeps the controller default
+	ReadCachePolicy  string   `json:"read_cache_policy"`  // ReadAhead, AdaptiveReadAhead or Off
+	WriteCachePolicy string   `json:"write_cache_policy"` // WriteThrough, ProtectedWriteBack or UnprotectedWriteBack
+}
+
+//VolumeResource ... Volume (virtual disk) of a storage controller
+type VolumeResource struct {
+	OdataID            string `json:"@odata.id"`
+	ID                 string `json:"Id"`
+	Name               string `json:"Name"`
+	RAIDType           string `json:"RAIDType"`
+	VolumeType         string `json:"VolumeType"`
+	CapacityBytes      int64  `json:"CapacityBytes"`
+	OptimumIOSizeBytes int64  `json:"OptimumIOSizeBytes"`
+	ReadCachePolicy    string `json:"ReadCachePolicy"`
+	WriteCachePolicy   string `json:"WriteCachePolicy"`
+	Links              struct {
+		Drives []Members `json:"Drives"`
+	} `json:"Links"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//VolumeData ... Virtual disk of a RAID controller
+type VolumeData struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	RAIDType         string   `json:"raid_type"`
+	CapacityBytes    int64    `json:"capacity_bytes"`
+	StripeSizeBytes  int64    `json:"stripe_size_bytes"`
+	ReadCachePolicy  string   `json:"read_cache_policy"`
+	WriteCachePolicy string   `json:"write_cache_policy"`
+	Drives           []string `json:"drives"`
+	Health           string   `json:"health"`
+	State            string   `json:"state"`
+}
+
+//SmartArrayHP ... Smart Array controller with its drives
+type SmartArrayHP struct {
+	ID             string            `json:"id"`
+	Model          string            `json:"model"`
+	Location       string            `json:"location"`
+	SerialNumber   string            `json:"serial_number"`
+	Firmware       string            `json:"firmware"`
+	Health         string            `json:"health"`
+	State          string            `json:"state"`
+	CacheSizeMiB   int               `json:"cache_size_mib"`
+	CacheHealth    string            `json:"cache_health"`
+	BatteryStatus  string            `json:"battery_status"`
+	LogicalDrives  []LogicalDriveHP  `json:"logical_drives"`
+	PhysicalDrives []PhysicalDriveHP `json:"physical_drives"`
+}
+
+//LogicalDriveHP ... Logical drive of a Smart Array controller
+type LogicalDriveHP struct {
+	Number          int    `json:"number"`
+	Name            string `json:"name"`
+	Raid            string `json:"raid"`
+	CapacityMiB     int64  `json:"capacity_mib"`
+	StripeSizeBytes int64  `json:"stripe_size_bytes"`
+	VolumeID        string `json:"volume_id"` // VolumeUniqueIdentifier, used by DeleteLogicalDriveHP
+	Health          string `json:"health"`
+	State           string `json:"state"`
+}
+
+//PhysicalDriveHP ... Physical drive of a Smart Array controller
+type PhysicalDriveHP struct {
+	Location      string `json:"location"` // port:box:bay, used as DataDrives by CreateLogicalDriveHP
+	Model         string `json:"model"`
+	SerialNumber  string `json:"serial_number"`
+	CapacityMiB   int64  `json:"capacity_mib"`
+	MediaType     string `json:"media_type"`
+	InterfaceType string `json:"interface_type"`
+	Firmware      string `json:"firmware"`
+	Health        string `json:"health"`
+	State         string `json:"state"`
+}
+
+//DriveResource ... Drive of a storage controller
+type DriveResource struct {
+	OdataID                       string   `json:"@odata.id"`
+	ID                            string   `json:"Id"`
+	Name                          string   `json:"Name"`
+	Model                         string   `json:"Model"`
+	SerialNumber                  string   `json:"SerialNumber"`
+	CapacityBytes                 int64    `json:"CapacityBytes"`
+	MediaType                     string   `json:"MediaType"`
+	Protocol                      string   `json:"Protocol"`
+	EncryptionAbility             string   `json:"EncryptionAbility"`
+	EncryptionStatus              string   `json:"EncryptionStatus"`
+	FailurePredicted              bool     `json:"FailurePredicted"`
+	PredictedMediaLifeLeftPercent *float64 `json:"PredictedMediaLifeLeftPercent"`
+	HotspareType                  string   `json:"HotspareType"`
+	Oem                           struct {
+		Dell struct {
+			DellPhysicalDisk struct {
+				RaidStatus                          string   `json:"RaidStatus"`
+				RemainingRatedWriteEndurancePercent *float64 `json:"RemainingRatedWriteEndurancePercent"`
+			} `json:"DellPhysicalDisk"`
+			DellPCIeSSD struct {
+				RemainingRatedWriteEndurancePercent *float64 `json:"RemainingRatedWriteEndurancePercent"`
+			} `json:"DellPCIeSSD"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+	Links struct {
+		Volumes []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Volumes"`
+	} `json:"Links"`
+	Actions struct {
+		DriveSecureErase struct {
+			Target string `json:"target"`
+		} `json:"#Drive.SecureErase"`
+	} `json:"Actions"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//DriveEraseTarget ... Drive a DriveErasePlan would erase
+type DriveEraseTarget struct {
+	ID            string `json:"id"`
+	Controller    string `json:"controller"`
+	Model         string `json:"model"`
+	SerialNumber  string `json:"serial_number"`
+	CapacityBytes int64  `json:"capacity_bytes"`
+	Method        string `json:"method"` // CryptographicErase (DellRaidService.SecureErase) for self-encrypting drives, SecureErase (Drive.SecureErase) otherwise
+	Supported     bool   `json:"supported"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+//DriveErasePlan ... Dry-run of a drive erase, nothing is erased until the plan is executed
+type DriveErasePlan struct {
+	Drives       []DriveEraseTarget `json:"drives"`
+	PollInterval time.Duration      `json:"poll_interval"` // 30s when zero
+	Timeout      time.Duration      `json:"timeout"`       // 4h when zero
+}
+
+//DriveEraseResult ... Outcome of erasing a drive
+type DriveEraseResult struct {
+	ID       string `json:"id"`
+	TaskURL  string `json:"task_url"`
+	JobState string `json:"job_state"`
+	Message  string `json:"message"`
+	Success  bool   `json:"success"`
+}
+
+//DriveHealth ... Wear and failure prediction of a drive
+type DriveHealth struct {
+	ID               string   `json:"id"`
+	Controller       string   `json:"controller"`
+	Model            string   `json:"model"`
+	SerialNumber     string   `json:"serial_number"`
+	MediaType        string   `json:"media_type"`
+	Protocol         string   `json:"protocol"`
+	CapacityBytes    int64    `json:"capacity_bytes"`
+	Health           string   `json:"health"`
+	State            string   `json:"state"`
+	FailurePredicted bool     `json:"failure_predicted"`
+	Hotspare         string   `json:"hotspare"`          // None, Global or Dedicated
+	LifeLeftPercent  *float64 `json:"life_left_percent"` // nil when the drive does not report its wear
+	PowerOnHours     *float64 `json:"power_on_hours,omitempty"`
+}
+
+//StorageResource ... Storage resource of a system with the Dell controller extras
+type StorageResource struct {
+	ID                 string `json:"Id"`
+	Name               string `json:"Name"`
+	StorageControllers []struct {
+		Model           string `json:"Model"`
+		FirmwareVersion string `json:"FirmwareVersion"`
+		CacheSummary    struct {
+			TotalCacheSizeMiB int `json:"TotalCacheSizeMiB"`
+			Status            struct {
+				Health string `json:"Health"`
+			} `json:"Status"`
+		} `json:"CacheSummary"`
+	} `json:"StorageControllers"`
+	Drives  []Members `json:"Drives"`
+	Volumes Members   `json:"Volumes"`
+	Oem     struct {
+		Dell struct {
+			DellController struct {
+				CacheSizeInMB             int    `json:"CacheSizeInMB"`
+				ControllerFirmwareVersion string `json:"ControllerFirmwareVersion"`
+			} `json:"DellController"`
+			DellControllerBattery struct {
+				PrimaryStatus string `json:"PrimaryStatus"`
+				RAIDState     string `json:"RAIDState"`
+			} `json:"DellControllerBattery"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+	Status struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+}
+
+//StorageControllerStatus ... Cache, battery and foreign configuration state of a RAID controller
+type StorageControllerStatus struct {
+	ID            string `json:"id"`
+	Model         string `json:"model"`
+	Firmware      string `json:"firmware"`
+	Health        string `json:"health"`
+	State         string `json:"state"`
+	CacheSizeMiB  int    `json:"cache_size_mib"`
+	CacheHealth   string `json:"cache_health"`
+	BatteryHealth string `json:"battery_health"`
+	BatteryState  string `json:"battery_state"`
+	WriteCache    string `json:"write_cache"` // WriteBack, WriteThrough or Mixed over the volumes, empty when unknown
+	ForeignConfig bool   `json:"foreign_config"`
+	CacheDegraded bool   `json:"cache_degraded"` // battery or cache module not healthy, write-back caching is at risk
+}